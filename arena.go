@@ -12,6 +12,7 @@
 //   - All memory is allocated via mmap and lives outside Go's garbage collector
 //   - Memory is never returned to the OS until Delete() is called
 //   - Reset() clears allocations but retains underlying memory pages
+//   - Building with -tags arenadebug poisons memory on Reset() to expose use-after-reset
 //
 // Allocator Strategies:
 //   - BUMP: Fastest, best for batch allocations or when arena is reset frequently
@@ -20,6 +21,8 @@
 package arena
 
 import (
+	"fmt"
+	"math/bits"
 	"syscall"
 	"unsafe"
 )
@@ -36,11 +39,30 @@ const (
 	BUDDY
 )
 
+// POISON_BYTE is written over memory reclaimed by Reset in builds with the
+// arenadebug tag, so reads through dangling arena pointers return 0xDEDEDE...
+// instead of plausible zeros or newer values. Release builds never poison.
+const POISON_BYTE = 0xDE
+
+// String returns the allocator type name (BUMP, SLAB or BUDDY)
+func (t Type) String() string {
+	switch t {
+	case BUMP:
+		return "BUMP"
+	case SLAB:
+		return "SLAB"
+	case BUDDY:
+		return "BUDDY"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
 // Arena is the beautiful multi-type facade.
 // Thread-safe: Multiple goroutines can safely call Alloc concurrently.
 // The underlying allocator handles synchronization internally.
 type Arena struct {
 	Allocator
+	sizeClasses bool // round MakeSlice capacities up to size classes
 }
 
 // New creates an arena. pages == 0 → 1 page (4 KiB default)
@@ -71,6 +93,189 @@ func (a *Arena) Delete() {
 	a.Allocator.Delete()
 }
 
+// String returns a short human-readable summary of the arena for logging, e.g.
+// "Arena{type=BUMP chunks=3 used=12KiB reserved=48KiB}".
+// It takes the allocator lock and walks the chunk list, so keep it off the hot path.
+// SLAB and BUDDY arenas report only their type; their usage is available through Stats.
+func (a *Arena) String() string {
+	var (
+		kind  Type
+		usage func() (int, uint64, uint64)
+	)
+	switch raw := a.Allocator.(type) {
+	case *BumpAllocator:
+		kind, usage = BUMP, raw.usage
+	case *childAllocator:
+		if raw.bump == nil {
+			return raw.parent.String()
+		}
+		kind, usage = BUMP, raw.bump.usage
+	case *SlabAllocator:
+		kind = SLAB
+	case *BuddyAllocator:
+		kind = BUDDY
+	default:
+		return fmt.Sprintf("Arena{type=%T}", raw)
+	}
+	if usage == nil {
+		return fmt.Sprintf("Arena{type=%s}", kind)
+	}
+	chunks, used, reserved := usage()
+	return fmt.Sprintf("Arena{type=%s chunks=%d used=%s reserved=%s}",
+		kind, chunks, formatBytes(used), formatBytes(reserved))
+}
+
+// Used returns the bytes handed out since the last Reset, including alignment padding
+// and the unused tails of filled chunks. Comparing Used before and after an operation
+// measures its arena footprint:
+//
+//	before := a.Used()
+//	parse(a, input)
+//	delta := a.Used() - before
+//
+// A child arena reports its parent's usage. SLAB and BUDDY arenas report 0; they
+// account for usage only through Stats.
+func (a *Arena) Used() uint64 {
+	b := a.bump()
+	if b == nil {
+		return 0
+	}
+	_, used, _ := b.usage()
+	return used
+}
+
+// AllocCount returns the number of allocations served since the arena was created.
+// Unlike Used it survives Reset, so tests can assert an operation's allocation count:
+//
+//	before := a.AllocCount()
+//	handle(a, req)
+//	if n := a.AllocCount() - before; n > 5 { ... }
+//
+// A child arena reports its parent's count. SLAB and BUDDY arenas do not count
+// allocations and report 0; their usage is available only through Stats.
+func (a *Arena) AllocCount() uint64 {
+	b := a.bump()
+	if b == nil {
+		return 0
+	}
+	allocs, _ := b.counts()
+	return allocs
+}
+
+// ResetCount returns the number of times the arena has been Reset. A child arena
+// reports its parent's count; rewinding the child itself is not counted.
+func (a *Arena) ResetCount() uint64 {
+	b := a.bump()
+	if b == nil {
+		return 0
+	}
+	_, resets := b.counts()
+	return resets
+}
+
+// Stats is a snapshot of an allocator's memory usage, for logging utilization and
+// sizing arenas: a HighWaterMark above the initial chunk size means the arena had to
+// grow, and creating it with more pages would avoid the extra chunks.
+type Stats struct {
+	BytesAllocated uint64 // bytes handed out since the last Reset, including padding
+	BytesReserved  uint64 // bytes mapped from the OS across all chunks
+	ChunkCount     int    // number of mapped chunks (regions for BUDDY, runs for SLAB)
+	HighWaterMark  uint64 // largest BytesAllocated seen since the arena was created
+}
+
+// Stats returns the arena's allocation statistics. A child arena reports its parent's.
+//
+// Example:
+//
+//	s := a.Stats()
+//	log.Printf("arena: %d/%d bytes, peak %d, %d chunks",
+//	    s.BytesAllocated, s.BytesReserved, s.HighWaterMark, s.ChunkCount)
+func (a *Arena) Stats() Stats {
+	if raw, ok := a.Allocator.(interface{ Stats() Stats }); ok {
+		return raw.Stats()
+	}
+	return Stats{}
+}
+
+// AllocSiteStat is the allocation total of one call site in an AllocProfile
+type AllocSiteStat struct {
+	Function string // fully qualified name of the calling function
+	File     string
+	Line     int
+	Count    uint64 // number of allocations
+	Bytes    uint64 // bytes requested, excluding alignment padding
+}
+
+// AllocProfile reports which code allocated from the arena: for each call site outside
+// this package, keyed by "file:line", the number of allocations and bytes requested
+// since the arena was created (Reset does not clear it). Like a heap profile, it
+// pinpoints the code paths filling an arena that grows unexpectedly. A child arena
+// reports its parent's profile.
+//
+// Profiling walks the stack (and allocates on the Go heap) on every allocation, so it
+// only runs in builds with -tags arenadebug; release builds pay nothing and
+// AllocProfile returns nil.
+//
+// Example:
+//
+//	for site, s := range a.AllocProfile() {
+//	    log.Printf("%s %s: %d allocs, %d bytes", site, s.Function, s.Count, s.Bytes)
+//	}
+func (a *Arena) AllocProfile() map[string]AllocSiteStat {
+	switch raw := a.Allocator.(type) {
+	case *BumpAllocator:
+		return raw.profile.snapshot()
+	case *BuddyAllocator:
+		return raw.profile.snapshot()
+	case *SlabAllocator:
+		return raw.profile.snapshot()
+	case *childAllocator:
+		return raw.parent.AllocProfile()
+	}
+	return nil
+}
+
+// formatBytes formats n with a binary unit suffix (B, KiB, MiB, GiB)
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for n/div >= unit && exp < 2 {
+		div *= unit
+		exp++
+	}
+	suffix := [...]string{"KiB", "MiB", "GiB"}[exp]
+	if n%div == 0 {
+		return fmt.Sprintf("%d%s", n/div, suffix)
+	}
+	return fmt.Sprintf("%.1f%s", float64(n)/float64(div), suffix)
+}
+
+// UseSizeClasses enables or disables size-class rounding for slice allocations.
+// When enabled, MakeSlice (and therefore Vec, Buffer and Append growth) rounds the
+// allocation up to the next size class, over-allocating slightly so that blocks
+// come in a few recurring sizes that a freeing allocator (slab/buddy) can reuse,
+// and so that later growth is absorbed by the extra capacity.
+func (a *Arena) UseSizeClasses(enabled bool) {
+	a.sizeClasses = enabled
+}
+
+// sizeClass rounds a byte count up to its size class: 16-byte steps up to 128 bytes,
+// then four evenly spaced classes per power of two (160, 192, 224, 256, 320, ...),
+// bounding the waste at 25%.
+func sizeClass(n int) int {
+	if n <= 16 {
+		return 16
+	}
+	if n <= 128 {
+		return (n + 15) &^ 15
+	}
+	step := 1 << (bits.Len(uint(n-1)) - 3)
+	return (n + step - 1) &^ (step - 1)
+}
+
 // Owns checks if the given pointer belongs to memory managed by this arena.
 // Returns true if the pointer was allocated by this arena and is still valid.
 // Returns false for nil pointers or pointers not managed by this arena.
@@ -78,6 +283,27 @@ func (a *Arena) Owns(ptr unsafe.Pointer) bool {
 	return a.Allocator.Owns(ptr)
 }
 
+// extend grows the allocation at ptr from oldSize to newSize bytes in place, if the
+// allocator can (bump arenas, when ptr is the most recent allocation).
+func (a *Arena) extend(ptr unsafe.Pointer, oldSize, newSize uint64) bool {
+	if b := a.bump(); b != nil {
+		return b.extend(ptr, oldSize, newSize)
+	}
+	return false
+}
+
+// bump returns the bump allocator backing the arena, looking through child arenas,
+// or nil if the arena is not bump-backed.
+func (a *Arena) bump() *BumpAllocator {
+	switch raw := a.Allocator.(type) {
+	case *BumpAllocator:
+		return raw
+	case *childAllocator:
+		return raw.bump
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------
 // Internal raw allocators (all support growing)
 // ---------------------------------------------------------------
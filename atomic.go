@@ -0,0 +1,54 @@
+package arena
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+const CACHE_LINE_SIZE = 64 // Size and alignment of an AtomicCounter, to avoid false sharing
+
+// AtomicCounter is an int64 counter in arena memory, safe for concurrent use.
+// Each counter is padded to and aligned on a cache line, so adjacent counters
+// (e.g. per-shard metrics from NewAtomicCounters) never share a line.
+// Counters must not be copied after first use.
+//
+// Example:
+//
+//	shards := arena.NewAtomicCounters(a, runtime.GOMAXPROCS(0))
+//	shards[shard].Add(1)
+type AtomicCounter struct {
+	value atomic.Int64
+	_     [CACHE_LINE_SIZE - 8]byte
+}
+
+// NewAtomicCounter allocates a zeroed, cache-line-aligned counter in the arena
+func NewAtomicCounter(a *Arena) *AtomicCounter {
+	return &NewAtomicCounters(a, 1)[0]
+}
+
+// NewAtomicCounters allocates n zeroed counters in the arena, each on its own cache line.
+// Index the slice to get a counter (&counters[i] or counters[i].Add); do not copy elements.
+func NewAtomicCounters(a *Arena, n int) []AtomicCounter {
+	if n <= 0 {
+		return nil
+	}
+	size := uint64(n) * uint64(unsafe.Sizeof(AtomicCounter{}))
+	ptr := a.Allocator.Alloc(size, CACHE_LINE_SIZE)
+	clear(unsafe.Slice((*byte)(ptr), size))
+	return unsafe.Slice((*AtomicCounter)(ptr), n)
+}
+
+// Add atomically adds delta to the counter and returns the new value
+func (c *AtomicCounter) Add(delta int64) int64 {
+	return c.value.Add(delta)
+}
+
+// Load atomically loads the counter value
+func (c *AtomicCounter) Load() int64 {
+	return c.value.Load()
+}
+
+// Store atomically stores v as the counter value
+func (c *AtomicCounter) Store(v int64) {
+	c.value.Store(v)
+}
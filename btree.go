@@ -0,0 +1,457 @@
+// btree.go — Arena-Backed B-Tree
+package arena
+
+import (
+	"iter"
+	"sync"
+)
+
+const DEFAULT_BTREE_DEGREE = 16 // Minimum degree used when NewBTree is given degree < 2
+
+// BTree is a thread-safe, ordered key-value store using a B-tree.
+// Unlike SkipList, its shape is deterministic and keys are stored in contiguous
+// per-node arrays, giving better cache locality and a guaranteed O(log n) height.
+// All operations (Get, Insert, Delete, Range) are protected by RWMutex.
+// Nodes (key, value and child arrays) are allocated entirely from the arena.
+type BTree[K ordered, V any] struct {
+	arena  *Arena
+	root   *bnode[K, V]
+	degree int
+	count  int
+	lock   sync.RWMutex
+}
+
+// bnode is a B-tree node holding up to 2*degree-1 keys.
+// Leaf nodes have no children; internal nodes have len(keys)+1 children.
+type bnode[K ordered, V any] struct {
+	keys     []K
+	vals     []V
+	children []*bnode[K, V]
+	leaf     bool
+}
+
+// NewBTree creates a new B-tree with the given minimum degree.
+// Every node except the root holds between degree-1 and 2*degree-1 keys.
+// A degree below 2 selects DEFAULT_BTREE_DEGREE.
+func NewBTree[K ordered, V any](a *Arena, degree int) *BTree[K, V] {
+	if degree < 2 {
+		degree = DEFAULT_BTREE_DEGREE
+	}
+	bt := &BTree[K, V]{
+		arena:  a,
+		degree: degree,
+	}
+	bt.root = bt.newNode(true)
+	return bt
+}
+
+// newNode allocates an empty node with full-size key/value/child arrays
+func (bt *BTree[K, V]) newNode(leaf bool) *bnode[K, V] {
+	n := MakeObject[bnode[K, V]](bt.arena)
+	n.keys = MakeSlice[K](bt.arena, 0, 2*bt.degree-1)
+	n.vals = MakeSlice[V](bt.arena, 0, 2*bt.degree-1)
+	if !leaf {
+		n.children = MakeSlice[*bnode[K, V]](bt.arena, 0, 2*bt.degree)
+	}
+	n.leaf = leaf
+	return n
+}
+
+// freeNode returns a node and its arrays to the arena
+func (bt *BTree[K, V]) freeNode(n *bnode[K, V]) {
+	DeleteSlice(bt.arena, n.keys[:cap(n.keys)])
+	DeleteSlice(bt.arena, n.vals[:cap(n.vals)])
+	DeleteSlice(bt.arena, n.children[:cap(n.children)])
+	DeleteObject(bt.arena, n)
+}
+
+// search returns the index of the first key >= key within a node
+func (n *bnode[K, V]) search(key K) int {
+	lo, hi := 0, len(n.keys)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if n.keys[mid] < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// insertAt inserts v at index i, shifting later elements right (capacity must allow it)
+func insertAt[T any](s []T, i int, v T) []T {
+	s = s[:len(s)+1]
+	copy(s[i+1:], s[i:len(s)-1])
+	s[i] = v
+	return s
+}
+
+// removeAt removes the element at index i, shifting later elements left
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = *new(T)
+	return s[:len(s)-1]
+}
+
+// Get finds a value by key
+func (bt *BTree[K, V]) Get(key K) (V, bool) {
+	bt.lock.RLock()
+	defer bt.lock.RUnlock()
+
+	x := bt.root
+	for {
+		i := x.search(key)
+		if i < len(x.keys) && x.keys[i] == key {
+			return x.vals[i], true
+		}
+		if x.leaf {
+			return *new(V), false
+		}
+		x = x.children[i]
+	}
+}
+
+// Contains checks if a key exists
+func (bt *BTree[K, V]) Contains(key K) bool {
+	_, ok := bt.Get(key)
+	return ok
+}
+
+// Insert adds or updates a key-value pair
+func (bt *BTree[K, V]) Insert(key K, value V) {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+
+	// Split a full root first so the descent never meets a full node
+	if len(bt.root.keys) == 2*bt.degree-1 {
+		root := bt.newNode(false)
+		root.children = append(root.children, bt.root)
+		bt.splitChild(root, 0)
+		bt.root = root
+	}
+
+	x := bt.root
+	for {
+		i := x.search(key)
+		if i < len(x.keys) && x.keys[i] == key {
+			x.vals[i] = value
+			return
+		}
+		if x.leaf {
+			x.keys = insertAt(x.keys, i, key)
+			x.vals = insertAt(x.vals, i, value)
+			bt.count++
+			return
+		}
+		if len(x.children[i].keys) == 2*bt.degree-1 {
+			bt.splitChild(x, i)
+			if x.keys[i] == key {
+				x.vals[i] = value
+				return
+			}
+			if x.keys[i] < key {
+				i++
+			}
+		}
+		x = x.children[i]
+	}
+}
+
+// splitChild splits the full child x.children[i] around its median key,
+// moving the median up into x
+func (bt *BTree[K, V]) splitChild(x *bnode[K, V], i int) {
+	var (
+		t = bt.degree
+		y = x.children[i]
+		z = bt.newNode(y.leaf)
+	)
+
+	z.keys = z.keys[:t-1]
+	z.vals = z.vals[:t-1]
+	copy(z.keys, y.keys[t:])
+	copy(z.vals, y.vals[t:])
+	if !y.leaf {
+		z.children = z.children[:t]
+		copy(z.children, y.children[t:])
+		clear(y.children[t:])
+		y.children = y.children[:t]
+	}
+
+	x.keys = insertAt(x.keys, i, y.keys[t-1])
+	x.vals = insertAt(x.vals, i, y.vals[t-1])
+	x.children = insertAt(x.children, i+1, z)
+
+	clear(y.keys[t-1:])
+	clear(y.vals[t-1:])
+	y.keys = y.keys[:t-1]
+	y.vals = y.vals[:t-1]
+}
+
+// Delete removes a key-value pair
+func (bt *BTree[K, V]) Delete(key K) bool {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+
+	if !bt.delete(bt.root, key) {
+		return false
+	}
+	bt.count--
+
+	// Shrink height when the root runs out of keys
+	if len(bt.root.keys) == 0 && !bt.root.leaf {
+		old := bt.root
+		bt.root = old.children[0]
+		bt.freeNode(old)
+	}
+	return true
+}
+
+// delete removes key from the subtree rooted at x.
+// The caller guarantees x has at least degree keys (or is the root).
+func (bt *BTree[K, V]) delete(x *bnode[K, V], key K) bool {
+	t := bt.degree
+	i := x.search(key)
+
+	if i < len(x.keys) && x.keys[i] == key {
+		if x.leaf {
+			x.keys = removeAt(x.keys, i)
+			x.vals = removeAt(x.vals, i)
+			return true
+		}
+
+		// Replace with predecessor or successor, or merge around the key
+		if y := x.children[i]; len(y.keys) >= t {
+			pk, pv := y.max()
+			x.keys[i], x.vals[i] = pk, pv
+			return bt.delete(y, pk)
+		}
+		if z := x.children[i+1]; len(z.keys) >= t {
+			sk, sv := z.min()
+			x.keys[i], x.vals[i] = sk, sv
+			return bt.delete(z, sk)
+		}
+		bt.merge(x, i)
+		return bt.delete(x.children[i], key)
+	}
+
+	if x.leaf {
+		return false
+	}
+
+	// Make sure the child we descend into has at least degree keys
+	if len(x.children[i].keys) == t-1 {
+		switch {
+		case i > 0 && len(x.children[i-1].keys) >= t:
+			bt.borrowFromPrev(x, i)
+		case i < len(x.keys) && len(x.children[i+1].keys) >= t:
+			bt.borrowFromNext(x, i)
+		case i < len(x.keys):
+			bt.merge(x, i)
+		default:
+			bt.merge(x, i-1)
+			i--
+		}
+	}
+	return bt.delete(x.children[i], key)
+}
+
+// borrowFromPrev rotates a key from x.children[i-1] through x into x.children[i]
+func (bt *BTree[K, V]) borrowFromPrev(x *bnode[K, V], i int) {
+	var (
+		child   = x.children[i]
+		sibling = x.children[i-1]
+		last    = len(sibling.keys) - 1
+	)
+
+	child.keys = insertAt(child.keys, 0, x.keys[i-1])
+	child.vals = insertAt(child.vals, 0, x.vals[i-1])
+	x.keys[i-1] = sibling.keys[last]
+	x.vals[i-1] = sibling.vals[last]
+	sibling.keys = removeAt(sibling.keys, last)
+	sibling.vals = removeAt(sibling.vals, last)
+
+	if !child.leaf {
+		child.children = insertAt(child.children, 0, sibling.children[last+1])
+		sibling.children = removeAt(sibling.children, last+1)
+	}
+}
+
+// borrowFromNext rotates a key from x.children[i+1] through x into x.children[i]
+func (bt *BTree[K, V]) borrowFromNext(x *bnode[K, V], i int) {
+	var (
+		child   = x.children[i]
+		sibling = x.children[i+1]
+	)
+
+	child.keys = append(child.keys, x.keys[i])
+	child.vals = append(child.vals, x.vals[i])
+	x.keys[i] = sibling.keys[0]
+	x.vals[i] = sibling.vals[0]
+	sibling.keys = removeAt(sibling.keys, 0)
+	sibling.vals = removeAt(sibling.vals, 0)
+
+	if !child.leaf {
+		child.children = append(child.children, sibling.children[0])
+		sibling.children = removeAt(sibling.children, 0)
+	}
+}
+
+// merge folds x.keys[i] and x.children[i+1] into x.children[i] and frees the sibling
+func (bt *BTree[K, V]) merge(x *bnode[K, V], i int) {
+	var (
+		child   = x.children[i]
+		sibling = x.children[i+1]
+	)
+
+	child.keys = append(child.keys, x.keys[i])
+	child.vals = append(child.vals, x.vals[i])
+	child.keys = append(child.keys, sibling.keys...)
+	child.vals = append(child.vals, sibling.vals...)
+	if !child.leaf {
+		child.children = append(child.children, sibling.children...)
+	}
+
+	x.keys = removeAt(x.keys, i)
+	x.vals = removeAt(x.vals, i)
+	x.children = removeAt(x.children, i+1)
+	bt.freeNode(sibling)
+}
+
+// min returns the smallest key-value pair in the subtree rooted at n
+func (n *bnode[K, V]) min() (K, V) {
+	for !n.leaf {
+		n = n.children[0]
+	}
+	return n.keys[0], n.vals[0]
+}
+
+// max returns the largest key-value pair in the subtree rooted at n
+func (n *bnode[K, V]) max() (K, V) {
+	for !n.leaf {
+		n = n.children[len(n.children)-1]
+	}
+	last := len(n.keys) - 1
+	return n.keys[last], n.vals[last]
+}
+
+// walk visits the subtree rooted at n in key order, stopping when f returns false
+func (n *bnode[K, V]) walk(f func(K, V) bool) bool {
+	for i := range n.keys {
+		if !n.leaf && !n.children[i].walk(f) {
+			return false
+		}
+		if !f(n.keys[i], n.vals[i]) {
+			return false
+		}
+	}
+	if !n.leaf {
+		return n.children[len(n.keys)].walk(f)
+	}
+	return true
+}
+
+// Range iterates over all key-value pairs in sorted order
+func (bt *BTree[K, V]) Range(f func(K, V) bool) {
+	bt.lock.RLock()
+	defer bt.lock.RUnlock()
+	bt.root.walk(f)
+}
+
+// All returns an iterator over all key-value pairs in sorted order.
+// This can be used with Go 1.23+ range-over-func:
+//
+//	for key, val := range btree.All() {
+//	    // process key, val
+//	}
+func (bt *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		bt.lock.RLock()
+		defer bt.lock.RUnlock()
+		bt.root.walk(yield)
+	}
+}
+
+// Keys returns an iterator over all keys in sorted order.
+// This can be used with Go 1.23+ range-over-func:
+//
+//	for key := range btree.Keys() {
+//	    // process key
+//	}
+func (bt *BTree[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		bt.lock.RLock()
+		defer bt.lock.RUnlock()
+		bt.root.walk(func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+// Values returns an iterator over all values in key-sorted order.
+// This can be used with Go 1.23+ range-over-func:
+//
+//	for val := range btree.Values() {
+//	    // process val
+//	}
+func (bt *BTree[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		bt.lock.RLock()
+		defer bt.lock.RUnlock()
+		bt.root.walk(func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+// Min returns the minimum key-value pair
+func (bt *BTree[K, V]) Min() (K, V, bool) {
+	bt.lock.RLock()
+	defer bt.lock.RUnlock()
+	if bt.count == 0 {
+		return *new(K), *new(V), false
+	}
+	k, v := bt.root.min()
+	return k, v, true
+}
+
+// Max returns the maximum key-value pair
+func (bt *BTree[K, V]) Max() (K, V, bool) {
+	bt.lock.RLock()
+	defer bt.lock.RUnlock()
+	if bt.count == 0 {
+		return *new(K), *new(V), false
+	}
+	k, v := bt.root.max()
+	return k, v, true
+}
+
+// Len returns the number of elements in the tree
+func (bt *BTree[K, V]) Len() int {
+	bt.lock.RLock()
+	defer bt.lock.RUnlock()
+	return bt.count
+}
+
+// Reset clears all elements from the tree
+func (bt *BTree[K, V]) Reset() {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+	bt.root = bt.newNode(true)
+	bt.count = 0
+}
+
+// CloneSlice returns a heap-allocated slice of key-value pairs in sorted order.
+// The returned slice is independent of the arena lifecycle and can be safely used
+// after the arena is deleted.
+func (bt *BTree[K, V]) CloneSlice() []Pair[K, V] {
+	bt.lock.RLock()
+	defer bt.lock.RUnlock()
+
+	if bt.count == 0 {
+		return nil
+	}
+
+	result := make([]Pair[K, V], 0, bt.count)
+	bt.root.walk(func(k K, v V) bool {
+		result = append(result, Pair[K, V]{Key: k, Value: v})
+		return true
+	})
+	return result
+}
@@ -2,47 +2,278 @@ package arena
 
 import (
 	"math/bits"
+	"sync"
 	"unsafe"
 )
 
+const (
+	BUDDY_MIN_ORDER = 4    // Smallest block is 1<<BUDDY_MIN_ORDER = 16 bytes (MIN_ALIGN)
+	BUDDY_FREE      = 0x80 // Flag in a head entry marking the block as free
+)
+
+// BuddyAllocator is a binary buddy allocator. Memory is divided into power-of-two
+// blocks: Alloc rounds the request up to a block size and splits a larger free
+// block in halves ("buddies") until one of that size is left, and Remove merges a
+// freed block with its buddy whenever both are free, so memory is returned to the
+// largest blocks possible. Blocks never exceed chunkSize, except for requests larger
+// than a chunk, which get a dedicated region.
+//
+// Like the bump allocator it grows rather than fails: when no free block is big
+// enough a new region is mapped. Reset returns every region to whole free chunks.
 type BuddyAllocator struct {
 	chunkSize uint64
-	free      [][]int
-	order     int
+	numChunks int
+	order     int             // order of a chunk, the largest block size
+	free      [64]*buddyBlock // free lists, indexed by block order
+	regions   []*buddyRegion  // mapped regions, in creation order
+	allocated uint64          // bytes in allocated blocks
+	peak      uint64          // highest value of allocated
+	profile   allocProfile
+	mtx       sync.Mutex
+}
+
+// buddyRegion is one mapped range of memory made of chunks of a single order
+type buddyRegion struct {
+	mem    []byte
+	order  int     // order of the region's chunks; buddies never merge above it
+	chunks int     // number of chunks
+	heads  []uint8 // per 16-byte unit: 0 if no block starts there, else order+1 (| BUDDY_FREE)
 }
 
+// buddyBlock is the free-list link stored in the first bytes of each free block
+type buddyBlock struct {
+	next, prev *buddyBlock
+}
+
+// NewBuddyAllocator creates a buddy allocator with numChunks chunks of chunkSize bytes,
+// which must be a power of two of at least 16. Chunks are the largest blocks that
+// buddies merge into.
 func NewBuddyAllocator(chunkSize, numChunks int) *BuddyAllocator {
-	if chunkSize&(chunkSize-1) != 0 {
-		panic("chunkSize must be power of 2")
+	if chunkSize < 1<<BUDDY_MIN_ORDER || chunkSize&(chunkSize-1) != 0 {
+		panic("chunkSize must be power of 2 and at least 16")
 	}
+	numChunks = max(numChunks, 1)
 	order := bits.Len(uint(chunkSize)) - 1
 	b := &BuddyAllocator{
 		chunkSize: uint64(chunkSize),
+		numChunks: numChunks,
 		order:     order,
-		free:      make([][]int, order+1),
 	}
-	// dummy, no chunks added
+	b.addRegion(order, numChunks)
 	return b
 }
 
+// Alloc allocates a zeroed block of at least size bytes, aligned to align (up to the
+// page size, the alignment of each region). The block size is the next power of two
+// of max(size, align, 16).
+// Note: Pointers returned by Alloc become invalid after Remove, Reset() or Delete().
 func (b *BuddyAllocator) Alloc(size, align uint64) unsafe.Pointer {
-	// dummy
-	return nil
+	b.profile.record(size)
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	order := max(bits.Len64(max(size, align)-1), BUDDY_MIN_ORDER)
+	j := b.freeOrder(order)
+	if j < 0 {
+		// grow: another set of chunks, or a dedicated region for oversized requests
+		if order <= b.order {
+			b.addRegion(b.order, b.numChunks)
+		} else {
+			b.addRegion(order, 1)
+		}
+		j = b.freeOrder(order)
+	}
+
+	blk := b.free[j]
+	b.unlink(j, blk)
+	r := b.regionOf(unsafe.Pointer(blk))
+	off := uintptr(unsafe.Pointer(blk)) - r.base()
+	// Split down to the requested order, freeing the upper half each time
+	for j > order {
+		j--
+		buddy := off + 1<<j
+		r.heads[buddy>>BUDDY_MIN_ORDER] = BUDDY_FREE | uint8(j+1)
+		b.push(j, (*buddyBlock)(unsafe.Pointer(&r.mem[buddy])))
+	}
+	r.heads[off>>BUDDY_MIN_ORDER] = uint8(order + 1)
+	b.allocated += 1 << order
+	b.peak = max(b.peak, b.allocated)
+
+	ptr := unsafe.Pointer(&r.mem[off])
+	clear(unsafe.Slice((*byte)(ptr), size))
+	return ptr
+}
+
+// Remove frees the block starting at ptr and merges it with its free buddies.
+// Pointers that are not the start of a live block (interior pointers, memory from
+// elsewhere, blocks already freed) are ignored. In arenadebug builds the freed block
+// is poisoned, apart from the free-list links written over its first 16 bytes.
+func (b *BuddyAllocator) Remove(ptr unsafe.Pointer) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	r := b.regionOf(ptr)
+	if r == nil {
+		return
+	}
+	off := uintptr(ptr) - r.base()
+	if off&(1<<BUDDY_MIN_ORDER-1) != 0 {
+		return
+	}
+	head := r.heads[off>>BUDDY_MIN_ORDER]
+	if head == 0 || head&BUDDY_FREE != 0 {
+		return
+	}
+	r.heads[off>>BUDDY_MIN_ORDER] = 0
+	order := int(head) - 1
+	b.allocated -= 1 << order
+	if poisonEnabled {
+		for i := range r.mem[off : off+1<<order] {
+			r.mem[off+uintptr(i)] = POISON_BYTE
+		}
+	}
+
+	// Coalesce while the buddy is a free block of the same order
+	for order < r.order {
+		buddy := off ^ 1<<order
+		if r.heads[buddy>>BUDDY_MIN_ORDER] != BUDDY_FREE|uint8(order+1) {
+			break
+		}
+		b.unlink(order, (*buddyBlock)(unsafe.Pointer(&r.mem[buddy])))
+		r.heads[buddy>>BUDDY_MIN_ORDER] = 0
+		off = min(off, buddy)
+		order++
+	}
+	r.heads[off>>BUDDY_MIN_ORDER] = BUDDY_FREE | uint8(order+1)
+	b.push(order, (*buddyBlock)(unsafe.Pointer(&r.mem[off])))
 }
 
+// Reset frees every block, returning each region to whole free chunks.
+// Note: All previously allocated pointers become invalid and should not be used.
 func (b *BuddyAllocator) Reset() {
-	// dummy
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.free = [64]*buddyBlock{}
+	b.allocated = 0
+	for _, r := range b.regions {
+		if poisonEnabled {
+			for i := range r.mem {
+				r.mem[i] = POISON_BYTE
+			}
+		}
+		r.release(b)
+	}
 }
 
+// Delete frees all memory allocated by the allocator.
+// Note: All previously allocated pointers become invalid and should not be used.
 func (b *BuddyAllocator) Delete() {
-	// dummy
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for _, r := range b.regions {
+		ReleasePages(r.mem)
+	}
+	b.regions = nil
+	b.free = [64]*buddyBlock{}
+	b.allocated = 0
 }
 
-func (b *BuddyAllocator) Remove(ptr unsafe.Pointer) {
-	// no op for buddy allocator
+// Stats reports the bytes in allocated blocks (rounded up to block sizes), the bytes
+// mapped across all regions and the high-water mark of allocated bytes.
+func (b *BuddyAllocator) Stats() Stats {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var reserved uint64
+	for _, r := range b.regions {
+		reserved += uint64(len(r.mem))
+	}
+	return Stats{
+		BytesAllocated: b.allocated,
+		BytesReserved:  reserved,
+		ChunkCount:     len(b.regions),
+		HighWaterMark:  b.peak,
+	}
 }
 
+// Owns checks if the given pointer belongs to memory managed by this allocator.
 func (b *BuddyAllocator) Owns(ptr unsafe.Pointer) bool {
-	// TODO: implement when buddy allocator is fully implemented
-	return false
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return ptr != nil && b.regionOf(ptr) != nil
+}
+
+// addRegion maps a region of at least n chunks of the given order (chunks smaller
+// than a page fill the whole page) and frees its chunks.
+// The caller must hold the lock (or own b exclusively).
+func (b *BuddyAllocator) addRegion(order, n int) {
+	mem := MakePages(n << order)
+	r := &buddyRegion{
+		mem:    mem,
+		order:  order,
+		chunks: len(mem) >> order,
+		heads:  make([]uint8, len(mem)>>BUDDY_MIN_ORDER),
+	}
+	b.regions = append(b.regions, r)
+	r.release(b)
+}
+
+// freeOrder returns the smallest order of at least order with a free block, or -1
+func (b *BuddyAllocator) freeOrder(order int) int {
+	for j := order; j < len(b.free); j++ {
+		if b.free[j] != nil {
+			return j
+		}
+	}
+	return -1
+}
+
+// regionOf returns the region containing ptr, or nil
+func (b *BuddyAllocator) regionOf(ptr unsafe.Pointer) *buddyRegion {
+	addr := uintptr(ptr)
+	for _, r := range b.regions {
+		if base := r.base(); addr >= base && addr < base+uintptr(len(r.mem)) {
+			return r
+		}
+	}
+	return nil
+}
+
+// push adds blk to the front of the free list for order
+func (b *BuddyAllocator) push(order int, blk *buddyBlock) {
+	*blk = buddyBlock{next: b.free[order]}
+	if blk.next != nil {
+		blk.next.prev = blk
+	}
+	b.free[order] = blk
+}
+
+// unlink removes blk from the free list for order
+func (b *BuddyAllocator) unlink(order int, blk *buddyBlock) {
+	if blk.prev != nil {
+		blk.prev.next = blk.next
+	} else {
+		b.free[order] = blk.next
+	}
+	if blk.next != nil {
+		blk.next.prev = blk.prev
+	}
+}
+
+// base returns the address of the region's first byte
+func (r *buddyRegion) base() uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(r.mem)))
+}
+
+// release marks every chunk of the region free, pushed so that the lowest chunk is
+// handed out first; the caller must hold the lock
+func (r *buddyRegion) release(b *BuddyAllocator) {
+	clear(r.heads)
+	for i := r.chunks - 1; i >= 0; i-- {
+		off := i << r.order
+		r.heads[off>>BUDDY_MIN_ORDER] = BUDDY_FREE | uint8(r.order+1)
+		b.push(r.order, (*buddyBlock)(unsafe.Pointer(&r.mem[off])))
+	}
 }
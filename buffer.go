@@ -1,6 +1,7 @@
 package arena
 
 import (
+	"bytes"
 	"unsafe"
 )
 
@@ -29,6 +30,11 @@ func (s *Buffer) Cap() int {
 	return cap(s.buf)
 }
 
+// Arena returns the arena backing the buffer
+func (s *Buffer) Arena() *Arena {
+	return s.arena
+}
+
 // Append appends bytes – never touches the Go heap
 func (s *Buffer) Append(bytes []byte) {
 	if len(bytes) == 0 {
@@ -43,6 +49,60 @@ func (s *Buffer) AppendString(str string) {
 	s.Append(unsafe.Slice(unsafe.StringData(str), len(str)))
 }
 
+// Write appends p, implementing io.Writer so a Buffer can be the target of
+// fmt.Fprintf and friends. It never returns an error.
+func (s *Buffer) Write(p []byte) (int, error) {
+	s.Append(p)
+	return len(p), nil
+}
+
+// WriteString appends str, implementing io.StringWriter. It never returns an error.
+func (s *Buffer) WriteString(str string) (int, error) {
+	s.AppendString(str)
+	return len(str), nil
+}
+
+// WriteRepeated appends n copies of c, growing the buffer at most once.
+// It panics if n is negative.
+func (s *Buffer) WriteRepeated(c byte, n int) {
+	if n < 0 {
+		panic("arena buffer: negative repeat count")
+	}
+	if n == 0 {
+		return
+	}
+	s.grow(n)
+	start := len(s.buf)
+	s.buf = s.buf[:start+n]
+	fill := s.buf[start:]
+	for i := range fill {
+		fill[i] = c
+	}
+}
+
+// WriteRepeatedString appends n copies of str, growing the buffer at most once and
+// filling by doubling copies. It panics if n is negative or the result would overflow.
+func (s *Buffer) WriteRepeatedString(str string, n int) {
+	if n < 0 {
+		panic("arena buffer: negative repeat count")
+	}
+	if n == 0 || len(str) == 0 {
+		return
+	}
+	if len(str) > (1<<63-1)/n {
+		panic("arena buffer: repeat count causes overflow")
+	}
+	total := len(str) * n
+	s.grow(total)
+	start := len(s.buf)
+	s.buf = s.buf[:start+total]
+	fill := s.buf[start:]
+	done := copy(fill, str)
+	for done < total {
+		done += copy(fill[done:], fill[:done])
+	}
+}
+
 // grow ensures capacity >= len + needed
 func (s *Buffer) grow(needed int) {
 	if len(s.buf)+needed <= cap(s.buf) {
@@ -72,6 +132,21 @@ func (s *Buffer) Bytes() []byte {
 	return s.buf
 }
 
+// Equal reports whether the buffer content equals b
+func (s *Buffer) Equal(b []byte) bool {
+	return bytes.Equal(s.buf, b)
+}
+
+// EqualString reports whether the buffer content equals str, without copying
+func (s *Buffer) EqualString(str string) bool {
+	return s.String() == str
+}
+
+// HasPrefix reports whether the buffer content begins with p
+func (s *Buffer) HasPrefix(p []byte) bool {
+	return bytes.HasPrefix(s.buf, p)
+}
+
 // CloneString returns a heap-allocated copy of the string that escapes the arena.
 // The returned string is independent of the arena lifecycle and can be safely
 // used after the arena is deleted. Use this when you need to preserve string
@@ -102,6 +177,20 @@ func NewBuffer(a *Arena) *Buffer {
 	}
 }
 
+// NewBufferSize creates a new Buffer with at least the given capacity pre-allocated;
+// arenas using size classes round it up. Use it when the final size is known so
+// appends up to that size never regrow (and never leave an outgrown buffer behind
+// in the arena). It panics if capacity is negative.
+func NewBufferSize(a *Arena, capacity int) *Buffer {
+	if capacity < 0 {
+		panic("arena buffer: negative capacity")
+	}
+	return &Buffer{
+		arena: a,
+		buf:   MakeSlice[byte](a, 0, capacity),
+	}
+}
+
 // NewBufferString creates a new Buffer with initial string content
 func NewBufferString(a *Arena, s string) *Buffer {
 	capacity := max(len(s)*2, 32)
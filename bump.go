@@ -1,7 +1,6 @@
 package arena
 
 import (
-	"sort"
 	"sync"
 	"unsafe"
 )
@@ -10,6 +9,10 @@ type BumpAllocator struct {
 	chunks  [][]byte
 	current int
 	offset  int
+	allocs  uint64 // successful Alloc calls, never reset
+	resets  uint64 // Reset calls
+	peak    uint64 // highest usage seen at a Reset or rollback
+	profile allocProfile
 	mtx     sync.Mutex
 }
 
@@ -24,6 +27,7 @@ func NewBumpAllocator(size int) *BumpAllocator {
 // It uses a bump allocation strategy, growing the heap as needed.
 // Note: Pointers returned by Alloc become invalid after Reset() or Delete() and should not be used.
 func (b *BumpAllocator) Alloc(size, align uint64) unsafe.Pointer {
+	b.profile.record(size)
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 	// log.Println("Allocating: ", size, align)
@@ -32,8 +36,8 @@ func (b *BumpAllocator) Alloc(size, align uint64) unsafe.Pointer {
 	aligned := (b.offset + int(align-1)) &^ int(align-1)
 	// log.Println("aligned: ", aligned)
 	// log.Println("current chunk size: ", len(b.chunks[b.current]))
-	if aligned+int(size) > len(b.chunks[b.current]) {
-		// grow
+	for aligned+int(size) > len(b.chunks[b.current]) {
+		// grow, skipping kept chunks (after Reset) that are too small for the request
 		if b.current+1 >= len(b.chunks) {
 			sz := max(int(size), len(b.chunks[0]))
 			// log.Println("creating page with size: ", sz)
@@ -45,14 +49,156 @@ func (b *BumpAllocator) Alloc(size, align uint64) unsafe.Pointer {
 	}
 	ptr := unsafe.Pointer(&b.chunks[b.current][aligned])
 	b.offset = aligned + int(size)
+	b.allocs++
+	if poisonEnabled {
+		clear(b.chunks[b.current][aligned:b.offset])
+	}
 	return ptr
 }
 
+// extend grows the allocation at ptr from oldSize to newSize bytes in place.
+// This only succeeds when it is the most recent allocation and the current chunk
+// has room; otherwise the caller must allocate a new block and copy.
+func (b *BumpAllocator) extend(ptr unsafe.Pointer, oldSize, newSize uint64) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if len(b.chunks) == 0 {
+		return false
+	}
+	var (
+		chunk = b.chunks[b.current]
+		base  = uintptr(unsafe.Pointer(unsafe.SliceData(chunk)))
+		start = uintptr(ptr)
+	)
+	if start < base || start+uintptr(oldSize) != base+uintptr(b.offset) {
+		return false
+	}
+	end := int(start-base) + int(newSize)
+	if end > len(chunk) {
+		return false
+	}
+	if poisonEnabled {
+		clear(chunk[b.offset:end])
+	}
+	b.offset = end
+	return true
+}
+
+// poisonFrom fills everything allocated since m with POISON_BYTE, so that stale
+// pointers into the reclaimed region read obviously-wrong data. The caller must
+// hold the lock. Only called in arenadebug builds.
+func (b *BumpAllocator) poisonFrom(m bumpMark) {
+	for i := m.current; i <= b.current && i < len(b.chunks); i++ {
+		var (
+			chunk = b.chunks[i]
+			start = 0
+			end   = len(chunk)
+		)
+		if i == m.current {
+			start = m.offset
+		}
+		if i == b.current {
+			end = b.offset
+		}
+		for j := start; j < end; j++ {
+			chunk[j] = POISON_BYTE
+		}
+	}
+}
+
+// bumpMark records a bump position so later allocations can be rolled back.
+type bumpMark struct {
+	current int
+	offset  int
+}
+
+// mark returns the current bump position.
+func (b *BumpAllocator) mark() bumpMark {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return bumpMark{current: b.current, offset: b.offset}
+}
+
+// rollback rewinds the bump position to m, reclaiming everything allocated since.
+// Chunks added after the mark are kept and reused by later allocations.
+// It reports false, changing nothing, if m is ahead of the current position
+// (the allocator was Reset or rolled back past m since it was taken).
+func (b *BumpAllocator) rollback(m bumpMark) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if m.current > b.current || (m.current == b.current && m.offset > b.offset) {
+		return false
+	}
+	b.peak = max(b.peak, b.used())
+	if poisonEnabled {
+		b.poisonFrom(m)
+	}
+	b.current, b.offset = m.current, m.offset
+	return true
+}
+
+// usage reports the number of chunks, the bytes handed out since the last Reset
+// (including alignment padding and the unused tails of filled chunks) and the total
+// bytes reserved across all chunks.
+func (b *BumpAllocator) usage() (chunks int, used, reserved uint64) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for _, c := range b.chunks {
+		reserved += uint64(len(c))
+	}
+	return len(b.chunks), b.used(), reserved
+}
+
+// used returns the bytes handed out since the last Reset; the caller must hold the lock
+func (b *BumpAllocator) used() uint64 {
+	if len(b.chunks) == 0 {
+		return 0
+	}
+	used := uint64(b.offset)
+	for _, c := range b.chunks[:b.current] {
+		used += uint64(len(c))
+	}
+	return used
+}
+
+// Stats reports the allocator's current usage, reserved memory and high-water mark.
+func (b *BumpAllocator) Stats() Stats {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var reserved uint64
+	for _, c := range b.chunks {
+		reserved += uint64(len(c))
+	}
+	used := b.used()
+	return Stats{
+		BytesAllocated: used,
+		BytesReserved:  reserved,
+		ChunkCount:     len(b.chunks),
+		HighWaterMark:  max(b.peak, used),
+	}
+}
+
+// counts returns the number of Alloc and Reset calls since the allocator was created
+func (b *BumpAllocator) counts() (allocs, resets uint64) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.allocs, b.resets
+}
+
 // Reset resets the allocator to its initial state, allowing reuse of allocated memory.
 // Note: All previously allocated pointers become invalid and should not be used.
 func (b *BumpAllocator) Reset() {
 	b.mtx.Lock()
+	b.peak = max(b.peak, b.used())
+	if poisonEnabled {
+		b.poisonFrom(bumpMark{})
+	}
 	b.current, b.offset = 0, 0
+	b.resets++
 	b.mtx.Unlock()
 }
 
@@ -82,24 +228,14 @@ func (b *BumpAllocator) Owns(ptr unsafe.Pointer) bool {
 		return false
 	}
 
-	ptrAddr := uintptr(ptr)
-
-	// Binary search to find the chunk that might contain the pointer
-	// Chunks are allocated sequentially, so they should be in increasing memory order
-	idx := sort.Search(len(b.chunks), func(i int) bool {
-		chunkStart := uintptr(unsafe.Pointer(&b.chunks[i][0]))
-		return ptrAddr < chunkStart
-	})
-
-	// If idx == 0, pointer is before first chunk
-	if idx == 0 {
-		return false
+	// Scan the chunks: mmap makes no promise about their order in memory (on Linux
+	// later chunks usually sit at lower addresses), so they cannot be binary searched
+	addr := uintptr(ptr)
+	for _, chunk := range b.chunks {
+		start := uintptr(unsafe.Pointer(unsafe.SliceData(chunk)))
+		if addr >= start && addr < start+uintptr(len(chunk)) {
+			return true
+		}
 	}
-
-	// Check the previous chunk (idx - 1) since sort.Search returns insertion point
-	chunk := b.chunks[idx-1]
-	chunkStart := uintptr(unsafe.Pointer(&chunk[0]))
-	chunkEnd := chunkStart + uintptr(len(chunk))
-
-	return ptrAddr >= chunkStart && ptrAddr < chunkEnd
+	return false
 }
@@ -0,0 +1,141 @@
+package arena
+
+import (
+	"unsafe"
+)
+
+// childAllocator serves allocations from its parent arena and, for bump-backed
+// parents, reclaims them by rewinding the parent to the point the child was created.
+type childAllocator struct {
+	parent *Arena
+	bump   *BumpAllocator // root bump allocator, nil if the parent is not bump-backed
+	start  Checkpoint     // the parent's position when the child was created
+}
+
+// Child creates a child arena that allocates from this arena's pages.
+// Resetting or deleting the child rewinds the parent to the child's creation point,
+// reclaiming only the child's allocations without a separate mmap.
+//
+// LIFO constraint: a bump arena has a single allocation pointer, so anything the
+// parent (or a sibling) allocates after the child was created is reclaimed too.
+// Children must be deleted in reverse creation order, and the parent should not
+// allocate while a child is live. For non-bump parents the child is a pass-through
+// and Reset/Delete do not reclaim memory. Once the parent has been Reset (or restored
+// past the child's creation point) the child's Reset and Delete reclaim nothing, as
+// the memory may already be reused by the parent. The child inherits the parent's
+// UseSizeClasses setting.
+//
+// Example:
+//
+//	a := arena.New(16, arena.BUMP)
+//	defer a.Delete()
+//
+//	req := a.Child()
+//	buf := arena.NewBuffer(req) // request-scoped
+//	// ...
+//	req.Delete() // buf's memory is returned to a
+func (a *Arena) Child() *Arena {
+	c := &childAllocator{parent: a, bump: a.bump(), start: a.Mark()}
+	return &Arena{Allocator: c, sizeClasses: a.sizeClasses}
+}
+
+// Scratch runs fn with scoped temporaries: it records the arena's bump position,
+// calls fn with the same arena, and on return (including by panic) rewinds to the
+// recorded position, reclaiming everything fn allocated while earlier allocations
+// stay valid. Nothing allocated inside fn may be used after Scratch returns.
+//
+// Scratch needs a bump-backed arena (or a child of one); for other allocator types
+// fn runs but nothing is reclaimed. As with Child, the rewind is LIFO: allocations
+// made by other goroutines while fn runs are reclaimed too. If fn (or anyone else)
+// Resets the arena, the rewind is skipped so newer allocations are left alone.
+//
+// Example:
+//
+//	for _, line := range lines {
+//	    a.Scratch(func(tmp *arena.Arena) {
+//	        fields := arena.NewStr(tmp).Fields(line)
+//	        total += len(fields) // fields is reclaimed when fn returns
+//	    })
+//	}
+func (a *Arena) Scratch(fn func(scratch *Arena)) {
+	defer a.Restore(a.Mark())
+	fn(a)
+}
+
+// Checkpoint is a saved allocation position of a bump arena, taken by Arena.Mark
+type Checkpoint struct {
+	bump   *BumpAllocator // nil if the arena is not bump-backed
+	mark   bumpMark
+	resets uint64 // the allocator's Reset count when the mark was taken
+}
+
+// Mark records the arena's current allocation position for a later Restore.
+// Checkpoints are cheap values; taking one allocates nothing.
+//
+// Example:
+//
+//	for _, req := range requests {
+//	    cp := a.Mark()
+//	    handle(a, req)
+//	    a.Restore(cp) // reclaim everything handle allocated
+//	}
+func (a *Arena) Mark() Checkpoint {
+	b := a.bump()
+	if b == nil {
+		return Checkpoint{}
+	}
+	_, resets := b.counts()
+	return Checkpoint{bump: b, mark: b.mark(), resets: resets}
+}
+
+// Restore rewinds the arena to cp, reclaiming everything allocated since Mark: those
+// allocations become invalid, exactly as after Reset, while earlier ones stay valid.
+// Checkpoints nest, restoring in reverse order of marking, and a checkpoint can be
+// restored repeatedly. As with Child and Scratch the rewind is LIFO, so allocations
+// made by other goroutines after the mark are reclaimed too.
+//
+// Restore reports false and does nothing if the arena is not bump-backed (SLAB, BUDDY),
+// if cp was taken from a different arena, or if the arena has since been Reset or
+// restored to an earlier checkpoint. A child arena shares its parent's checkpoints.
+func (a *Arena) Restore(cp Checkpoint) bool {
+	b := a.bump()
+	if b == nil || cp.bump != b {
+		return false
+	}
+	if _, resets := b.counts(); resets != cp.resets {
+		return false
+	}
+	return b.rollback(cp.mark)
+}
+
+// Alloc allocates from the parent arena.
+func (c *childAllocator) Alloc(size, align uint64) unsafe.Pointer {
+	return c.parent.Allocator.Alloc(size, align)
+}
+
+// Reset reclaims everything allocated since the child was created, unless the
+// parent was Reset in the meantime. The child remains usable afterwards.
+func (c *childAllocator) Reset() {
+	c.parent.Restore(c.start)
+}
+
+// Delete reclaims everything allocated since the child was created.
+// The parent's pages are not released.
+func (c *childAllocator) Delete() {
+	c.Reset()
+}
+
+// Remove forwards individual deallocations to the parent.
+func (c *childAllocator) Remove(ptr unsafe.Pointer) {
+	c.parent.Allocator.Remove(ptr)
+}
+
+// Owns checks if the given pointer belongs to memory managed by the parent arena.
+func (c *childAllocator) Owns(ptr unsafe.Pointer) bool {
+	return c.parent.Allocator.Owns(ptr)
+}
+
+// Stats reports the parent arena's statistics.
+func (c *childAllocator) Stats() Stats {
+	return c.parent.Stats()
+}
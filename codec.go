@@ -0,0 +1,251 @@
+package arena
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// ErrUnsupportedType is returned by the built-in codecs for types other than
+// strings, byte slices, booleans and numbers. Use EncodeWith/DecodeMapWith with
+// custom codec functions for such types.
+var ErrUnsupportedType = errors.New("arena: type has no built-in codec")
+
+// EncodeFunc writes a single value to w.
+type EncodeFunc[T any] func(w *Writer, v T) error
+
+// DecodeFunc reads a single value from r, allocating any variable-size data in a.
+type DecodeFunc[T any] func(a *Arena, r *Reader) (T, error)
+
+// EncodeValue is the built-in codec used by Map.Encode.
+// Strings and byte slices are written as a uvarint length followed by the bytes,
+// signed integers as zig-zag varints, unsigned integers as uvarints, floats as
+// 8 little-endian bytes and booleans as a single byte.
+func EncodeValue[T any](w *Writer, v T) error {
+	var temp [binary.MaxVarintLen64]byte
+	switch x := any(v).(type) {
+	case string:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(len(x))))
+		w.WriteString(x)
+	case []byte:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(len(x))))
+		w.Write(x)
+	case bool:
+		if x {
+			w.WriteByte(1)
+		} else {
+			w.WriteByte(0)
+		}
+	case int:
+		w.Write(binary.AppendVarint(temp[:0], int64(x)))
+	case int8:
+		w.Write(binary.AppendVarint(temp[:0], int64(x)))
+	case int16:
+		w.Write(binary.AppendVarint(temp[:0], int64(x)))
+	case int32:
+		w.Write(binary.AppendVarint(temp[:0], int64(x)))
+	case int64:
+		w.Write(binary.AppendVarint(temp[:0], x))
+	case uint:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(x)))
+	case uint8:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(x)))
+	case uint16:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(x)))
+	case uint32:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(x)))
+	case uint64:
+		w.Write(binary.AppendUvarint(temp[:0], x))
+	case uintptr:
+		w.Write(binary.AppendUvarint(temp[:0], uint64(x)))
+	case float32:
+		w.Write(binary.LittleEndian.AppendUint64(temp[:0], math.Float64bits(float64(x))))
+	case float64:
+		w.Write(binary.LittleEndian.AppendUint64(temp[:0], math.Float64bits(x)))
+	default:
+		return ErrUnsupportedType
+	}
+	return nil
+}
+
+// DecodeValue is the built-in codec used by DecodeMap, the reverse of EncodeValue.
+// Strings and byte slices are copied into a.
+func DecodeValue[T any](a *Arena, r *Reader) (T, error) {
+	var (
+		zero T
+		out  any
+	)
+	switch any(zero).(type) {
+	case string:
+		data, err := r.readPrefixed()
+		if err != nil {
+			return zero, err
+		}
+		out = a.MakeString(UnsafeString(data))
+	case []byte:
+		data, err := r.readPrefixed()
+		if err != nil {
+			return zero, err
+		}
+		clone := MakeSlice[byte](a, len(data), len(data))
+		copy(clone, data)
+		out = clone
+	case bool:
+		if r.offset >= len(r.buffer) {
+			return zero, io.ErrUnexpectedEOF
+		}
+		out = r.buffer[r.offset] != 0
+		r.offset++
+	case int, int8, int16, int32, int64:
+		if r.offset >= len(r.buffer) {
+			return zero, io.ErrUnexpectedEOF
+		}
+		x, n := binary.Varint(r.buffer[r.offset:])
+		if n <= 0 {
+			return zero, io.ErrUnexpectedEOF
+		}
+		r.offset += n
+		return castInt[T](uint64(x)), nil
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		if r.offset >= len(r.buffer) {
+			return zero, io.ErrUnexpectedEOF
+		}
+		x, n := binary.Uvarint(r.buffer[r.offset:])
+		if n <= 0 {
+			return zero, io.ErrUnexpectedEOF
+		}
+		r.offset += n
+		return castInt[T](x), nil
+	case float32, float64:
+		if len(r.buffer)-r.offset < 8 {
+			return zero, io.ErrUnexpectedEOF
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(r.buffer[r.offset:]))
+		r.offset += 8
+		if _, ok := any(zero).(float32); ok {
+			out = float32(f)
+		} else {
+			out = f
+		}
+	default:
+		return zero, ErrUnsupportedType
+	}
+	return out.(T), nil
+}
+
+// castInt truncates x to the integer type T (two's complement, native endianness)
+func castInt[T any](x uint64) T {
+	var v T
+	switch unsafe.Sizeof(v) {
+	case 1:
+		*(*uint8)(unsafe.Pointer(&v)) = uint8(x)
+	case 2:
+		*(*uint16)(unsafe.Pointer(&v)) = uint16(x)
+	case 4:
+		*(*uint32)(unsafe.Pointer(&v)) = uint32(x)
+	default:
+		*(*uint64)(unsafe.Pointer(&v)) = x
+	}
+	return v
+}
+
+// readPrefixed reads a uvarint length followed by that many bytes, without copying
+func (r *Reader) readPrefixed() ([]byte, error) {
+	if r.offset >= len(r.buffer) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	length, n := binary.Uvarint(r.buffer[r.offset:])
+	if n <= 0 || length > uint64(len(r.buffer)-r.offset-n) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	start := r.offset + n
+	r.offset = start + int(length)
+	return r.buffer[start:r.offset], nil
+}
+
+// Encode writes all entries of the map to w using the built-in codecs.
+// The format is a uvarint entry count followed by each key and value as written
+// by EncodeValue. Use EncodeWith for key or value types without a built-in codec.
+//
+// Example:
+//
+//	w := arena.NewWriter(a)
+//	if err := m.Encode(w); err != nil { ... }
+//	restored, err := arena.DecodeMap[string, int](b, arena.NewReader(b, w.Bytes()))
+func (m *Map[K, V]) Encode(w *Writer) error {
+	return m.EncodeWith(w, EncodeValue[K], EncodeValue[V])
+}
+
+// EncodeWith writes all entries of the map to w using the supplied codec functions.
+// If a codec fails, w is truncated back to its length before the call.
+func (m *Map[K, V]) EncodeWith(w *Writer, key EncodeFunc[K], value EncodeFunc[V]) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var (
+		temp  [binary.MaxVarintLen64]byte
+		start = w.Offset()
+	)
+	w.Write(binary.AppendUvarint(temp[:0], uint64(m.count)))
+	for i := range m.cap {
+		e, ok := m.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for e != nil {
+			if err := key(w, e.key); err != nil {
+				w.Truncate(start)
+				return err
+			}
+			if err := value(w, e.val); err != nil {
+				w.Truncate(start)
+				return err
+			}
+			e = e.next
+		}
+	}
+	return nil
+}
+
+// DecodeMap reads a map written by Map.Encode into a new Map allocated in a.
+func DecodeMap[K comparable, V any](a *Arena, r *Reader) (*Map[K, V], error) {
+	return DecodeMapWith(a, r, DecodeValue[K], DecodeValue[V])
+}
+
+// DecodeMapWith reads a map written by Map.EncodeWith into a new Map allocated in a,
+// using the supplied codec functions.
+// On error r is rewound to where decoding started. The partly decoded map is left in
+// a; callers that want it reclaimed can wrap the call in a.Mark and a.Restore, as
+// long as no other goroutine allocates from a meanwhile.
+func DecodeMapWith[K comparable, V any](a *Arena, r *Reader, key DecodeFunc[K], value DecodeFunc[V]) (*Map[K, V], error) {
+	start := r.offset
+	fail := func(err error) (*Map[K, V], error) {
+		r.offset = start
+		return nil, err
+	}
+
+	if r.offset >= len(r.buffer) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	count, n := binary.Uvarint(r.buffer[r.offset:])
+	if n <= 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	r.offset += n
+
+	m := NewMap[K, V](a)
+	for range count {
+		k, err := key(a, r)
+		if err != nil {
+			return fail(err)
+		}
+		v, err := value(a, r)
+		if err != nil {
+			return fail(err)
+		}
+		m.Set(k, v)
+	}
+	return m, nil
+}
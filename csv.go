@@ -0,0 +1,151 @@
+package arena
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrCSVQuote is returned (wrapped with the line number) for an unterminated quoted
+// field, a quote inside an unquoted field, or text after a closing quote
+var ErrCSVQuote = errors.New(`arena csv: bare, extraneous or missing " in field`)
+
+// CSVReader parses comma-separated records (RFC 4180) from a string. Fields are
+// substrings of the input wherever possible; only quoted fields containing escaped
+// quotes ("") are copied, into the arena. The field slice is reused across records,
+// so steady-state reading allocates nothing.
+//
+// Quoted fields may contain commas, newlines and "" escapes. Records end at LF or
+// CRLF, and empty lines are skipped. Records may have differing numbers of fields.
+//
+// Example:
+//
+//	r := arena.NewCSVReader(a, data)
+//	for {
+//	    record, err := r.Read()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(record)
+//	}
+type CSVReader struct {
+	arena  *Arena
+	str    *Str
+	data   string
+	offset int
+	fields []string
+	err    error
+}
+
+// NewCSVReader creates a CSVReader over data. The returned fields alias data, so it
+// must stay valid (and unmodified) while they are in use.
+func NewCSVReader(a *Arena, data string) *CSVReader {
+	return &CSVReader{arena: a, str: NewStr(a), data: data}
+}
+
+// Read returns the fields of the next record, or io.EOF when no records remain.
+// The returned slice is reused by the next Read; copy it to keep it longer.
+// After a parse error, every Read returns that error.
+func (r *CSVReader) Read() ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	// Skip empty lines
+	for r.offset < len(r.data) {
+		if r.data[r.offset] == '\n' {
+			r.offset++
+		} else if strings.HasPrefix(r.data[r.offset:], "\r\n") {
+			r.offset += 2
+		} else {
+			break
+		}
+	}
+	if r.offset >= len(r.data) {
+		return nil, io.EOF
+	}
+
+	r.fields = r.fields[:0]
+	for {
+		field, more, err := r.readField()
+		if err != nil {
+			r.err = err
+			return nil, err
+		}
+		r.fields = Append(r.arena, r.fields, field)
+		if !more {
+			return r.fields, nil
+		}
+	}
+}
+
+// readField parses one field at the current offset and consumes its delimiter.
+// more reports whether another field follows in the same record.
+func (r *CSVReader) readField() (field string, more bool, err error) {
+	data, pos := r.data, r.offset
+	if pos < len(data) && data[pos] == '"' {
+		// Quoted field: find the closing quote, skipping "" escapes
+		var (
+			start   = pos + 1
+			escaped = false
+		)
+		pos = start
+		for {
+			i := strings.IndexByte(data[pos:], '"')
+			if i < 0 {
+				return "", false, r.errorAt(start - 1)
+			}
+			pos = pos + i + 1
+			if pos < len(data) && data[pos] == '"' {
+				escaped = true
+				pos++
+				continue
+			}
+			break
+		}
+		field = data[start : pos-1]
+		if escaped {
+			field = r.str.Replace(field, `""`, `"`, -1)
+		}
+	} else {
+		end := pos + strings.IndexAny(data[pos:], ",\n")
+		if end < pos {
+			end = len(data)
+		}
+		field = data[pos:end]
+		if end == len(data) || data[end] == '\n' {
+			field = strings.TrimSuffix(field, "\r") // CRLF line ending
+		}
+		if strings.IndexByte(field, '"') >= 0 {
+			return "", false, r.errorAt(pos)
+		}
+		pos = end
+	}
+
+	// Consume the delimiter
+	switch {
+	case pos >= len(data):
+		r.offset = pos
+		return field, false, nil
+	case data[pos] == ',':
+		r.offset = pos + 1
+		return field, true, nil
+	case data[pos] == '\n':
+		r.offset = pos + 1
+		return field, false, nil
+	case strings.HasPrefix(data[pos:], "\r\n"):
+		r.offset = pos + 2
+		return field, false, nil
+	}
+	return "", false, r.errorAt(pos)
+}
+
+// errorAt wraps ErrCSVQuote with the 1-based line number of byte offset pos
+func (r *CSVReader) errorAt(pos int) error {
+	line := bytes.Count(UnsafeBytes(r.data[:pos]), []byte{'\n'}) + 1
+	return fmt.Errorf("arena csv: line %d: %w", line, ErrCSVQuote)
+}
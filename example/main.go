@@ -39,7 +39,7 @@ func main() {
 	a := arena.New(4096, arena.BUMP)
 	defer a.Delete()
 
-	fmt.Println("=== ArenaSlice Examples ===")
+	fmt.Println("=== Vec Examples ===")
 
 	// 1. Integer slice
 	fmt.Println("\n1. Integer Slice:")
@@ -0,0 +1,93 @@
+package arena
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// FrozenMap is an immutable, read-optimized snapshot of a Map, produced by Map.Freeze.
+// Entries live in a single open-addressed slot array in arena memory, sized to a
+// power of two less than half full, so lookups probe few slots and walk no chains.
+// Since it never changes, reads take no lock and are safe from any goroutine.
+//
+// Example:
+//
+//	b := arena.NewMap[string, int](a)
+//	for i, name := range names {
+//	    b.Set(name, i)
+//	}
+//	table := b.Freeze()
+//	id, ok := table.Get("alice")
+type FrozenMap[K comparable, V any] struct {
+	slots  []frozenSlot[K, V]
+	mask   uint64
+	count  int
+	seed   maphash.Seed
+	hasher func(K) uint64
+}
+
+// frozenSlot is one open-addressing slot; used is false for empty slots
+type frozenSlot[K comparable, V any] struct {
+	hash uint64
+	key  K
+	val  V
+	used bool
+}
+
+// Freeze copies the map's current entries into a new FrozenMap allocated in the map's
+// arena. Later changes to the Map are not reflected in the FrozenMap. Keys keep the
+// Map's hash function and stored hashes, so freezing does not rehash any key.
+func (m *Map[K, V]) Freeze() *FrozenMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	n := 1 << bits.Len(uint(m.count*2)) // less than half full
+	f := &FrozenMap[K, V]{
+		slots:  MakeSliceN[frozenSlot[K, V]](m.arena, n),
+		mask:   uint64(n - 1),
+		count:  m.count,
+		seed:   m.seed,
+		hasher: m.hasher,
+	}
+	clear(f.slots) // memory reused after Reset is not zero
+	for i := range m.cap {
+		e, ok := m.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for ; e != nil; e = e.next {
+			j := e.hash & f.mask
+			for f.slots[j].used {
+				j = (j + 1) & f.mask
+			}
+			f.slots[j] = frozenSlot[K, V]{hash: e.hash, key: e.key, val: e.val, used: true}
+		}
+	}
+	return f
+}
+
+// Get returns the value for key and true, or the zero value and false if absent
+func (f *FrozenMap[K, V]) Get(key K) (V, bool) {
+	h := hashKey(f.seed, f.hasher, key)
+	for i := h & f.mask; f.slots[i].used; i = (i + 1) & f.mask {
+		if s := &f.slots[i]; s.hash == h && s.key == key {
+			return s.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Len returns the number of entries
+func (f *FrozenMap[K, V]) Len() int {
+	return f.count
+}
+
+// Range calls f for each entry in unspecified order, stopping early if f returns false
+func (f *FrozenMap[K, V]) Range(fn func(K, V) bool) {
+	for i := range f.slots {
+		if s := &f.slots[i]; s.used && !fn(s.key, s.val) {
+			return
+		}
+	}
+}
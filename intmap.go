@@ -0,0 +1,142 @@
+package arena
+
+import (
+	"iter"
+	"math/bits"
+	"sync"
+)
+
+// IntMap is a thread-safe map from small non-negative integer keys to values,
+// stored as a directly indexed arena slice plus a presence bitset. For dense keys
+// such as entity IDs 0..N it gives O(1) access with no hashing; memory is
+// proportional to the largest key, so it suits dense or bounded keys only.
+//
+// Example:
+//
+//	names := arena.NewIntMap[string](a)
+//	names.Set(42, "alice")
+//	name, ok := names.Get(42)
+type IntMap[V any] struct {
+	mu      sync.RWMutex
+	arena   *Arena
+	values  []V
+	present []uint64 // bit k%64 of word k/64 is set when key k is present
+	count   int
+}
+
+// NewIntMap creates a new, empty IntMap
+func NewIntMap[V any](a *Arena) *IntMap[V] {
+	return &IntMap[V]{arena: a}
+}
+
+// Set inserts or updates the value for key, growing the backing to fit.
+// It panics if key is negative.
+func (m *IntMap[V]) Set(key int, value V) {
+	if key < 0 {
+		panic("arena intmap: negative key")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key >= len(m.values) {
+		m.grow(key + 1)
+	}
+	word, bit := key/64, uint64(1)<<(key%64)
+	if m.present[word]&bit == 0 {
+		m.present[word] |= bit
+		m.count++
+	}
+	m.values[key] = value
+}
+
+// Get returns the value for key and true if present
+func (m *IntMap[V]) Get(key int) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.has(key) {
+		return m.values[key], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains checks if key is present
+func (m *IntMap[V]) Contains(key int) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.has(key)
+}
+
+// Delete removes key, reporting whether it was present
+func (m *IntMap[V]) Delete(key int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.has(key) {
+		return false
+	}
+	m.present[key/64] &^= uint64(1) << (key % 64)
+	m.values[key] = *new(V)
+	m.count--
+	return true
+}
+
+// Len returns the number of present keys
+func (m *IntMap[V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.count
+}
+
+// Range calls f for each present key in ascending order, stopping if f returns false
+func (m *IntMap[V]) Range(f func(int, V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for w, word := range m.present {
+		for word != 0 {
+			key := w*64 + bits.TrailingZeros64(word)
+			if !f(key, m.values[key]) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// All returns an iterator over all present keys and values in ascending key order
+func (m *IntMap[V]) All() iter.Seq2[int, V] {
+	return m.Range
+}
+
+// Reset removes all keys while keeping the backing capacity
+func (m *IntMap[V]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.values)
+	clear(m.present)
+	m.count = 0
+}
+
+// has reports whether key is present; the caller must hold the lock
+func (m *IntMap[V]) has(key int) bool {
+	return key >= 0 && key < len(m.values) && m.present[key/64]&(uint64(1)<<(key%64)) != 0
+}
+
+// grow reallocates the backing to hold at least n keys, rounded up to whole bitset words
+func (m *IntMap[V]) grow(n int) {
+	capacity := max(len(m.values)*2, n, 64)
+	capacity = (capacity + 63) &^ 63
+
+	values := MakeSlice[V](m.arena, capacity, capacity)
+	present := MakeSlice[uint64](m.arena, capacity/64, capacity/64)
+	copied := copy(values, m.values)
+	clear(values[copied:]) // arena memory reused after Reset is not zero
+	copied = copy(present, m.present)
+	clear(present[copied:])
+
+	DeleteSlice(m.arena, m.values)
+	DeleteSlice(m.arena, m.present)
+	m.values, m.present = values, present
+}
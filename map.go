@@ -1,9 +1,12 @@
 package arena
 
 import (
+	"cmp"
 	"hash/maphash"
 	"iter"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -21,6 +24,18 @@ type Map[K comparable, V any] struct {
 	cap     int
 	mask    uint64
 	seed    maphash.Seed
+	hasher  func(K) uint64   // optional user-supplied hash, replaces the maphash path
+	shrink  bool             // rehash into fewer buckets when Delete leaves the map sparse
+	free    *entry[K, V]     // deleted entries, linked through entry.free and reused by Set
+	iters   atomic.Int32     // unfinished MapIters; Set does not recycle entries while any exist
+	flights map[K]*flight[V] // in-progress ComputeOnce calls, created on first use
+}
+
+// flight is a ComputeOnce computation that other callers for the same key wait on
+type flight[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	ok  bool // false if fn panicked; waiters then retry
 }
 
 // entry is a node in the hash chain (linked list)
@@ -29,6 +44,7 @@ type entry[K comparable, V any] struct {
 	key  K
 	val  V
 	next *entry[K, V]
+	free *entry[K, V] // next entry on the map's free list, while this one is deleted
 }
 
 // NewMap creates a new Map with separate chaining for collision resolution
@@ -51,62 +67,74 @@ func NewMap[K comparable, V any](a *Arena) *Map[K, V] {
 	return m
 }
 
-// hash function using maphash for better performance and security
+// NewMapHashed creates a new Map that hashes keys with the supplied function
+// instead of the built-in maphash path. This is useful for integer keys where
+// a cheap mixing function outperforms maphash, or for keys with a natural hash.
+// A nil hash selects the default hashing.
+//
+// Example:
+//
+//	m := arena.NewMapHashed[uint64, string](a, func(k uint64) uint64 {
+//	    return k * 0x9E3779B97F4A7C15
+//	})
+func NewMapHashed[K comparable, V any](a *Arena, hash func(K) uint64) *Map[K, V] {
+	m := NewMap[K, V](a)
+	m.hasher = hash
+	return m
+}
+
+// UseAutoShrink enables or disables automatic shrinking. When enabled, a Delete that
+// leaves fewer than Capacity()/8 entries rehashes into half as many buckets (down to
+// INITIAL_BUCKET_COUNT), keeping Range and iteration cost proportional to the live
+// entries in long-lived, delete-heavy maps. Leave it off for maps that will refill,
+// since shrinking adds rehash work to Delete. While a MapIter is unfinished, Delete
+// does not shrink; the next Delete after it finishes does.
+// Note: in a bump arena the old bucket array is not reclaimed until the arena is reset.
+func (m *Map[K, V]) UseAutoShrink(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shrink = enabled
+}
+
+// hash function using maphash for better performance and security.
+// Uses the one-shot maphash.String/maphash.Comparable helpers instead of building a
+// maphash.Hash per call, so hashing never allocates and skips the SetSeed setup.
+// maphash.Comparable hashes by value, so struct keys containing strings or
+// padding hash consistently with ==.
 func (m *Map[K, V]) hash(key K) uint64 {
-	var h maphash.Hash
-	h.SetSeed(m.seed)
-
-	// Write key data to hasher
-	switch v := any(key).(type) {
-	case string:
-		h.WriteString(v)
-	case int:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case int8:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case int16:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case int32:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case int64:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case uint:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case uint8:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case uint16:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case uint32:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case uint64:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	case uintptr:
-		writeBytes(&h, unsafe.Pointer(&v), unsafe.Sizeof(v))
-	default:
-		// For other comparable types, use their memory representation
-		writeBytes(&h, unsafe.Pointer(&key), unsafe.Sizeof(key))
-	}
-
-	return h.Sum64()
-}
-
-// writeBytes writes raw bytes to the hasher
-func writeBytes(h *maphash.Hash, ptr unsafe.Pointer, size uintptr) {
-	data := unsafe.Slice((*byte)(ptr), size)
-	h.Write(data)
+	return hashKey(m.seed, m.hasher, key)
+}
+
+// hashKey hashes key with hasher if set, otherwise with maphash under seed
+func hashKey[K comparable](seed maphash.Seed, hasher func(K) uint64, key K) uint64 {
+	if hasher != nil {
+		return hasher(key)
+	}
+	if v, ok := any(key).(string); ok {
+		return maphash.String(seed, v)
+	}
+	return maphash.Comparable(seed, key)
 }
 
 // Set inserts or updates a key-value pair using separate chaining
 func (m *Map[K, V]) Set(key K, value V) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.set(key, value)
+}
 
+// set inserts or updates a key-value pair; the caller must hold the write lock
+func (m *Map[K, V]) set(key K, value V) {
+	m.setHashed(key, value, m.hash(key))
+}
+
+// setHashed is set with a precomputed hash of key; the caller must hold the write lock
+func (m *Map[K, V]) setHashed(key K, value V, hash uint64) {
 	// Grow when load factor > 0.75
 	if m.count > m.cap*3/4 {
 		m.grow()
 	}
 
-	hash := m.hash(key)
 	index := hash & m.mask
 	head, ok := m.buckets.Get(int(index))
 	if !ok {
@@ -123,9 +151,8 @@ func (m *Map[K, V]) Set(key K, value V) {
 		e = e.next
 	}
 
-	// Key not found, allocate new entry and prepend to chain
-	// Note: entries are freed immediately on Delete/Reset via arena.Remove()
-	item := (*entry[K, V])(m.arena.Alloc(uint64(unsafe.Sizeof(entry[K, V]{})), 8))
+	// Key not found, take an entry from the free list (or the arena) and prepend to chain
+	item := m.newEntry()
 
 	*item = entry[K, V]{
 		hash: hash,
@@ -143,12 +170,163 @@ func (m *Map[K, V]) Get(key K) (V, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if m.cap == 0 {
-		var zero V
-		return zero, false
+	if e := m.find(key); e != nil {
+		return e.val, true
 	}
+	var zero V
+	return zero, false
+}
 
+// GetWithHash is like Get but also returns the hash computed for key, so that a
+// following SetWithHash on the same key can skip rehashing it. This is an advanced
+// API for read-modify-write on keys that are expensive to hash, such as long strings.
+//
+// Example:
+//
+//	n, _, h := counts.GetWithHash(path)
+//	counts.SetWithHash(path, n+1, h)
+//
+// The two calls are separate critical sections, so another writer may change the
+// entry in between.
+func (m *Map[K, V]) GetWithHash(key K) (V, bool, uint64) {
 	hash := m.hash(key)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e := m.findHashed(key, hash); e != nil {
+		return e.val, true, hash
+	}
+	var zero V
+	return zero, false, hash
+}
+
+// SetWithHash inserts or updates a key-value pair using a hash previously returned
+// by GetWithHash for the same key on the same Map. Passing any other hash silently
+// corrupts the map: the entry becomes unreachable through Get, Set and Delete.
+func (m *Map[K, V]) SetWithHash(key K, value V, hash uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setHashed(key, value, hash)
+}
+
+// GetOr returns the value for key, or def if the key is absent
+func (m *Map[K, V]) GetOr(key K, def V) V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e := m.find(key); e != nil {
+		return e.val
+	}
+	return def
+}
+
+// GetOrZero returns the value for key, or the zero value if the key is absent
+func (m *Map[K, V]) GetOrZero(key K) V {
+	v, _ := m.Get(key)
+	return v
+}
+
+// ComputeOnce returns the value for key, calling fn to compute and store it if the key
+// is absent. Concurrent callers for the same missing key wait for a single call of fn
+// (the singleflight pattern), while different keys compute in parallel: the map lock
+// is only held for bookkeeping, never while fn runs.
+// fn must not call ComputeOnce for the same key. If fn panics, nothing is stored,
+// the panic propagates to its caller, and waiting callers retry the computation.
+//
+// Example:
+//
+//	tmpl := cache.ComputeOnce(name, func() *Template { return parse(name) })
+func (m *Map[K, V]) ComputeOnce(key K, fn func() V) V {
+	for {
+		m.mu.RLock()
+		if e := m.find(key); e != nil {
+			v := e.val
+			m.mu.RUnlock()
+			return v
+		}
+		m.mu.RUnlock()
+
+		m.mu.Lock()
+		if e := m.find(key); e != nil {
+			v := e.val
+			m.mu.Unlock()
+			return v
+		}
+		if f, ok := m.flights[key]; ok {
+			m.mu.Unlock()
+			f.wg.Wait()
+			if f.ok {
+				return f.val
+			}
+			continue // the computing call panicked; try again
+		}
+		f := &flight[V]{}
+		f.wg.Add(1)
+		if m.flights == nil {
+			m.flights = make(map[K]*flight[V])
+		}
+		m.flights[key] = f
+		m.mu.Unlock()
+
+		m.compute(key, f, fn)
+		return f.val
+	}
+}
+
+// compute runs fn for a ComputeOnce flight, stores the result and releases waiters,
+// even if fn panics
+func (m *Map[K, V]) compute(key K, f *flight[V], fn func() V) {
+	defer func() {
+		m.mu.Lock()
+		if f.ok {
+			m.set(key, f.val)
+		}
+		delete(m.flights, key)
+		m.mu.Unlock()
+		f.wg.Done()
+	}()
+	f.val = fn()
+	f.ok = true
+}
+
+// GetRef returns a pointer to the value stored for key, avoiding a copy of large values.
+// The value can be read or mutated in place through the pointer.
+// ⚠️ CAUTION: The pointer aliases the map's arena entry. It is not protected by the
+// map's lock, and becomes invalid once the key is deleted or the map is Reset (a later
+// Set may recycle the entry for another key, and the pointer then aliases that key's
+// value); it must not be used concurrently with writers or retained past the next
+// mutation.
+//
+// Example:
+//
+//	if p, ok := m.GetRef("counter"); ok {
+//	    p.Hits++ // in-place update, no Set needed
+//	}
+func (m *Map[K, V]) GetRef(key K) (*V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e := m.find(key); e != nil {
+		return &e.val, true
+	}
+	return nil, false
+}
+
+// find returns the entry for key or nil; the caller must hold the lock
+func (m *Map[K, V]) find(key K) *entry[K, V] {
+	if m.cap == 0 {
+		return nil
+	}
+	return m.findHashed(key, m.hash(key))
+}
+
+// findHashed is find with a precomputed hash of key; the caller must hold the lock
+func (m *Map[K, V]) findHashed(key K, hash uint64) *entry[K, V] {
+	if m.cap == 0 {
+		return nil
+	}
+
 	index := hash & m.mask
 	e, ok := m.buckets.Get(int(index))
 	if !ok {
@@ -158,22 +336,46 @@ func (m *Map[K, V]) Get(key K) (V, bool) {
 	// Walk the chain
 	for e != nil {
 		if e.hash == hash && e.key == key {
-			return e.val, true
+			return e
 		}
 		e = e.next
 	}
+	return nil
+}
 
-	var zero V
-	return zero, false
+// newEntry pops an entry from the free list, allocating from the arena only when
+// the list is empty or a MapIter is unfinished; the caller must hold the write lock.
+// Entries have a fixed size per Map instantiation, so recycling them slab-style
+// keeps churny maps (many Set/Delete cycles) from leaking nodes in bump arenas,
+// where arena.Remove is a no-op.
+func (m *Map[K, V]) newEntry() *entry[K, V] {
+	if e := m.free; e != nil && m.iters.Load() == 0 {
+		m.free = e.free
+		return e
+	}
+	return Alloc[entry[K, V]](m.arena)
 }
 
-// Delete removes a key from the chain and frees the entry memory
+// freeEntry pushes e onto the free list; the caller must hold the write lock.
+// The entry keeps its key, value and chain link until it is reused, so a MapIter
+// stopped on it carries on along the chain it was deleted from.
+func (m *Map[K, V]) freeEntry(e *entry[K, V]) {
+	e.free = m.free
+	m.free = e
+}
+
+// Delete removes a key from the chain and recycles the entry for later Sets
 func (m *Map[K, V]) Delete(key K) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.remove(key)
+}
 
+// remove unlinks and frees the entry for key, reporting whether it existed;
+// the caller must hold the write lock
+func (m *Map[K, V]) remove(key K) bool {
 	if m.cap == 0 {
-		return
+		return false
 	}
 
 	hash := m.hash(key)
@@ -196,14 +398,20 @@ func (m *Map[K, V]) Delete(key K) {
 				// Removing from middle/end of chain
 				prev.next = curr.next
 			}
-			// Free the entry memory via arena
-			m.arena.Remove(unsafe.Pointer(curr))
+			// Recycle the entry for the next Set
+			m.freeEntry(curr)
 			m.count--
-			return
+			// Rehashing would move entries under an open MapIter, so shrinking waits
+			// for a Delete made once every iterator has finished
+			if m.shrink && m.cap > INITIAL_BUCKET_COUNT && m.count < m.cap/8 && m.iters.Load() == 0 {
+				m.rehash(max(m.cap/2, INITIAL_BUCKET_COUNT))
+			}
+			return true
 		}
 		prev = curr
 		curr = curr.next
 	}
+	return false
 }
 
 // Range calls f for each entry in all chains
@@ -226,6 +434,80 @@ func (m *Map[K, V]) Range(f func(K, V) bool) {
 	}
 }
 
+// RangeValues calls f with the value of each entry, stopping early if f returns false.
+// Unlike Range it never copies keys, which matters for aggregations over maps with
+// large key types.
+func (m *Map[K, V]) RangeValues(f func(V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := range m.cap {
+		e, ok := m.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for e != nil {
+			if !f(e.val) {
+				return
+			}
+			e = e.next
+		}
+	}
+}
+
+// RangeKeys calls f with the key of each entry, stopping early if f returns false.
+// Unlike Range it never copies values.
+func (m *Map[K, V]) RangeKeys(f func(K) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := range m.cap {
+		e, ok := m.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for e != nil {
+			if !f(e.key) {
+				return
+			}
+			e = e.next
+		}
+	}
+}
+
+// MapRangeOrdered calls f for each entry of m in ascending key order, stopping early
+// if f returns false. Entries are collected into a scratch slice and sorted, so each
+// call costs O(n log n) time; use a SkipList or BTree when ordered iteration is the
+// common case. The scratch slice lives on the Go heap rather than in m's arena, where
+// a bump allocator would never reclaim it and repeated calls would grow the arena.
+func MapRangeOrdered[K ordered, V any](m *Map[K, V], f func(K, V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.count == 0 {
+		return
+	}
+	entries := make([]*entry[K, V], 0, m.count)
+	for i := range m.cap {
+		e, ok := m.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for e != nil {
+			entries = append(entries, e)
+			e = e.next
+		}
+	}
+	slices.SortFunc(entries, func(a, b *entry[K, V]) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	for _, e := range entries {
+		if !f(e.key, e.val) {
+			return
+		}
+	}
+}
+
 // Len returns number of entries
 func (m *Map[K, V]) Len() int {
 	m.mu.RLock()
@@ -233,15 +515,21 @@ func (m *Map[K, V]) Len() int {
 	return m.count
 }
 
+// Capacity returns the number of buckets
+func (m *Map[K, V]) Capacity() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cap
+}
+
 // grow doubles the bucket array and rehashes all entries
 func (m *Map[K, V]) grow() {
-	obkt := m.buckets.Slice()
-	ocap := m.cap
+	m.rehash(max(m.cap*2, INITIAL_BUCKET_COUNT))
+}
 
-	ncap := ocap * 2
-	if ncap < INITIAL_BUCKET_COUNT {
-		ncap = INITIAL_BUCKET_COUNT
-	}
+// rehash moves all entries into a new bucket array of ncap buckets (a power of two)
+func (m *Map[K, V]) rehash(ncap int) {
+	obkt := m.buckets.Slice()
 
 	// Allocate new bucket array using Vec
 	nbkt := NewVec[*entry[K, V]](m.arena)
@@ -268,7 +556,7 @@ func (m *Map[K, V]) grow() {
 			index := e.hash & m.mask
 			head, ok := nbkt.Get(int(index))
 			if !ok {
-				panic("arena map: bucket index out of bounds during grow")
+				panic("arena map: bucket index out of bounds during rehash")
 			}
 			e.next = head
 			nbkt.Set(int(index), e)
@@ -280,11 +568,11 @@ func (m *Map[K, V]) grow() {
 
 	// Sanity check
 	if m.count != ocount {
-		panic("arena map: lost entries during grow")
+		panic("arena map: lost entries during rehash")
 	}
 }
 
-// Reset frees all entries and clears the map while keeping capacity
+// Reset recycles all entries and clears the map while keeping capacity
 func (m *Map[K, V]) Reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -297,7 +585,7 @@ func (m *Map[K, V]) Reset() {
 		}
 		for e != nil {
 			next := e.next
-			m.arena.Remove(unsafe.Pointer(e))
+			m.freeEntry(e)
 			e = next
 		}
 		m.buckets.Set(i, nil)
@@ -305,6 +593,128 @@ func (m *Map[K, V]) Reset() {
 	m.count = 0
 }
 
+// DiffKeys returns the keys of m that are not in other, in unspecified order. The
+// result is allocated in m's arena. With IntersectKeys and UnionKeys it answers
+// "which keys were added or removed between two snapshots" without Range+Get loops.
+//
+// Example:
+//
+//	added := next.DiffKeys(prev)
+//	removed := prev.DiffKeys(next)
+func (m *Map[K, V]) DiffKeys(other *Map[K, V]) []K {
+	unlock := rlockPair(m, other)
+	defer unlock()
+
+	keys := MakeSliceCap[K](m.arena, m.count)
+	m.rangeEntries(func(e *entry[K, V]) {
+		if m == other || other.find(e.key) != nil {
+			return
+		}
+		keys = Append(m.arena, keys, e.key)
+	})
+	return keys
+}
+
+// IntersectKeys returns the keys present in both m and other, in unspecified order.
+// The smaller map is walked and the larger probed. The result is allocated in m's arena.
+func (m *Map[K, V]) IntersectKeys(other *Map[K, V]) []K {
+	unlock := rlockPair(m, other)
+	defer unlock()
+
+	small, large := m, other
+	if large.count < small.count {
+		small, large = large, small
+	}
+	keys := MakeSliceCap[K](m.arena, small.count)
+	small.rangeEntries(func(e *entry[K, V]) {
+		if small == large || large.find(e.key) != nil {
+			keys = Append(m.arena, keys, e.key)
+		}
+	})
+	return keys
+}
+
+// UnionKeys returns every key present in m or other exactly once: the keys of m,
+// followed by the keys only in other. The result is allocated in m's arena.
+func (m *Map[K, V]) UnionKeys(other *Map[K, V]) []K {
+	unlock := rlockPair(m, other)
+	defer unlock()
+
+	keys := MakeSliceCap[K](m.arena, m.count+other.count)
+	m.rangeEntries(func(e *entry[K, V]) {
+		keys = Append(m.arena, keys, e.key)
+	})
+	if m != other {
+		other.rangeEntries(func(e *entry[K, V]) {
+			if m.find(e.key) == nil {
+				keys = Append(m.arena, keys, e.key)
+			}
+		})
+	}
+	return keys
+}
+
+// PutFiltered copies into m every entry of src for which pred returns true, overwriting
+// existing keys. The locks of both maps are held throughout (taken in address order),
+// so pred must not call methods of m or src. Keys and values are copied shallowly.
+//
+// Example:
+//
+//	active := arena.NewMap[string, *User](a)
+//	active.PutFiltered(users, func(_ string, u *User) bool { return u.Active })
+func (m *Map[K, V]) PutFiltered(src *Map[K, V], pred func(K, V) bool) {
+	if m == src {
+		return // every entry is already present
+	}
+	if uintptr(unsafe.Pointer(m)) < uintptr(unsafe.Pointer(src)) {
+		m.mu.Lock()
+		src.mu.RLock()
+	} else {
+		src.mu.RLock()
+		m.mu.Lock()
+	}
+	defer m.mu.Unlock()
+	defer src.mu.RUnlock()
+
+	src.rangeEntries(func(e *entry[K, V]) {
+		if pred(e.key, e.val) {
+			m.set(e.key, e.val)
+		}
+	})
+}
+
+// rangeEntries calls f for every entry; the caller must hold a lock
+func (m *Map[K, V]) rangeEntries(f func(*entry[K, V])) {
+	for i := range m.cap {
+		e, ok := m.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for ; e != nil; e = e.next {
+			f(e)
+		}
+	}
+}
+
+// rlockPair read-locks a and b in address order, so that concurrent a.DiffKeys(b)
+// and b.DiffKeys(a) cannot deadlock, and returns the matching unlock. A map paired
+// with itself is locked once.
+func rlockPair[K comparable, V any](a, b *Map[K, V]) func() {
+	if a == b {
+		a.mu.RLock()
+		return a.mu.RUnlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.mu.RLock()
+	b.mu.RLock()
+	return func() {
+		b.mu.RUnlock()
+		a.mu.RUnlock()
+	}
+}
+
 // Clone returns a heap-allocated standard Go map with all entries from the Map.
 // The returned map is independent of the arena lifecycle and can be safely used
 // after the arena is deleted. Use this when you need to preserve map data beyond
@@ -332,6 +742,43 @@ func (m *Map[K, V]) Clone() map[K]V {
 	return result
 }
 
+// CloneInto copies all entries into a new Map allocated in dst, keeping any
+// custom hash function. String keys and values are copied into dst; other values
+// are copied shallowly, so pointers still refer to their original memory.
+// Use CloneMapDeepInto for values that are themselves arena-backed containers.
+func (m *Map[K, V]) CloneInto(dst *Arena) *Map[K, V] {
+	return CloneMapDeepInto(m, dst, func(v V, a *Arena) V { return cloneString(a, v) })
+}
+
+// CloneMapDeepInto copies all entries of src into a new Map allocated in dst, calling
+// cloneVal to deep-copy each value into dst. String keys are copied into dst.
+// This is the way to promote nested structures such as Map[string, *Vec[int]],
+// whose inner containers would otherwise still live in the source arena.
+//
+// Example:
+//
+//	clone := arena.CloneMapDeepInto(src, dst, func(v *arena.Vec[int], a *arena.Arena) *arena.Vec[int] {
+//	    return arena.Ptr(a, *arena.NewVec(a, v.Slice()...))
+//	})
+func CloneMapDeepInto[K comparable, V any](src *Map[K, V], dst *Arena, cloneVal func(V, *Arena) V) *Map[K, V] {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
+
+	clone := NewMapHashed[K, V](dst, src.hasher)
+	clone.shrink = src.shrink
+	for i := range src.cap {
+		e, ok := src.buckets.Get(i)
+		if !ok {
+			panic("arena map: bucket index out of bounds")
+		}
+		for e != nil {
+			clone.set(cloneString(dst, e.key), cloneVal(e.val, dst))
+			e = e.next
+		}
+	}
+	return clone
+}
+
 // -----------------------------
 // Iterator support
 // -----------------------------
@@ -423,7 +870,9 @@ func (m *Map[K, V]) All() iter.Seq2[K, V] {
 	}
 }
 
-// MapIter provides pull-based iteration over map entries
+// MapIter provides pull-based iteration over map entries.
+// Deleting keys while iterating is safe: deleted entries are not recycled until every
+// MapIter on the map has finished, either by Next reporting false or by Close.
 type MapIter[K comparable, V any] struct {
 	m       *Map[K, V]
 	index   int
@@ -461,6 +910,9 @@ func (m *Map[K, V]) Iter() *MapIter[K, V] {
 		}
 		it.index++
 	}
+	if it.current != nil {
+		m.iters.Add(1)
+	}
 
 	return it
 }
@@ -494,7 +946,23 @@ func (it *MapIter[K, V]) Next() (K, V, bool) {
 			}
 			it.index++
 		}
+		if it.current == nil {
+			it.m.iters.Add(-1)
+		}
 	}
 
 	return key, val, true
 }
+
+// Close ends the iteration early. Call it on an iterator abandoned before Next
+// reports false, since the map does not recycle deleted entries while it is open.
+// Closing a finished iterator is a no-op.
+func (it *MapIter[K, V]) Close() {
+	it.m.mu.RLock()
+	defer it.m.mu.RUnlock()
+
+	if it.current != nil {
+		it.current = nil
+		it.m.iters.Add(-1)
+	}
+}
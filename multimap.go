@@ -0,0 +1,151 @@
+package arena
+
+import (
+	"iter"
+	"sync"
+)
+
+// MultiMap groups many values under one key, with every value list stored in arena memory.
+// It replaces the Map[K, *Vec[V]] get-check-create pattern with a single locked Add.
+// Thread-safe: All operations are protected by an RWMutex.
+//
+// Example:
+//
+//	events := arena.NewMultiMap[string, int](a)
+//	events.Add("alice", 1)
+//	events.Add("alice", 2)
+//	events.Add("bob", 3)
+//	fmt.Println(events.Get("alice")) // [1 2]
+type MultiMap[K comparable, V any] struct {
+	mu     sync.RWMutex
+	arena  *Arena
+	groups *Map[K, *Vec[V]]
+	count  int // total number of values across all keys
+}
+
+// NewMultiMap creates a new, empty MultiMap
+func NewMultiMap[K comparable, V any](a *Arena) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		arena:  a,
+		groups: NewMap[K, *Vec[V]](a),
+	}
+}
+
+// Add appends value to the list for key, creating the list on first use
+func (mm *MultiMap[K, V]) Add(key K, value V) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	if e := mm.groups.find(key); e != nil {
+		e.val.AppendOne(value)
+	} else {
+		// The list header lives in the arena too: map entries are not scanned by the
+		// GC, so a heap-allocated header would be collected while still referenced
+		mm.groups.set(key, Ptr(mm.arena, *NewVec(mm.arena, value)))
+	}
+	mm.count++
+}
+
+// Get returns the values for key in insertion order, or nil if the key is absent.
+// The returned slice is a zero-copy view that is only valid until the next Add or
+// Delete for the same key.
+func (mm *MultiMap[K, V]) Get(key K) []V {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+
+	if e := mm.groups.find(key); e != nil {
+		return e.val.Slice()
+	}
+	return nil
+}
+
+// Count returns the number of values stored for key
+func (mm *MultiMap[K, V]) Count(key K) int {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+
+	if e := mm.groups.find(key); e != nil {
+		return e.val.Len()
+	}
+	return 0
+}
+
+// Contains checks if key has at least one value
+func (mm *MultiMap[K, V]) Contains(key K) bool {
+	return mm.Count(key) > 0
+}
+
+// Delete removes key and frees its value list.
+// Returns the number of values removed.
+func (mm *MultiMap[K, V]) Delete(key K) int {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	e := mm.groups.find(key)
+	if e == nil {
+		return 0
+	}
+	values := e.val
+	mm.groups.remove(key)
+	n := values.Len()
+	DeleteSlice(mm.arena, values.data[:cap(values.data)])
+	DeleteObject(mm.arena, values)
+	mm.count -= n
+	return n
+}
+
+// Len returns the number of distinct keys
+func (mm *MultiMap[K, V]) Len() int {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	return mm.groups.count
+}
+
+// Size returns the total number of values across all keys
+func (mm *MultiMap[K, V]) Size() int {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	return mm.count
+}
+
+// Keys returns an iterator over all distinct keys
+func (mm *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		mm.mu.RLock()
+		defer mm.mu.RUnlock()
+
+		for i := range mm.groups.cap {
+			e, ok := mm.groups.buckets.Get(i)
+			if !ok {
+				panic("arena map: bucket index out of bounds")
+			}
+			for e != nil {
+				if !yield(e.key) {
+					return
+				}
+				e = e.next
+			}
+		}
+	}
+}
+
+// All returns an iterator over each key and its values
+func (mm *MultiMap[K, V]) All() iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		mm.mu.RLock()
+		defer mm.mu.RUnlock()
+
+		for i := range mm.groups.cap {
+			e, ok := mm.groups.buckets.Get(i)
+			if !ok {
+				panic("arena map: bucket index out of bounds")
+			}
+			for e != nil {
+				if !yield(e.key, e.val.Slice()) {
+					return
+				}
+				e = e.next
+			}
+		}
+	}
+}
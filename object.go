@@ -4,6 +4,20 @@ import (
 	"unsafe"
 )
 
+const SMALL_STRING_SIZE = 16 // Strings up to this many bytes are copied without memmove
+
+// MIN_ALIGN is the minimum alignment of every typed allocation (Alloc, MakeObject,
+// MakeSlice and container nodes). Types whose natural alignment is larger get their
+// natural alignment instead; see alignOf.
+const MIN_ALIGN = 16
+
+// alignOf returns the alignment for allocating values of type T:
+// max(unsafe.Alignof(T), MIN_ALIGN)
+func alignOf[T any]() uint64 {
+	var zero T
+	return uint64(max(unsafe.Alignof(zero), MIN_ALIGN))
+}
+
 // Alloc allocates and returns a pointer to a new instance of type T in the arena.
 // The object is zero-initialized. This is useful for creating instances without
 // heap allocation. The pointer remains valid until the arena is deleted or reset.
@@ -18,7 +32,7 @@ func Alloc[T any](a *Arena) *T {
 	if size == 0 {
 		size = 1
 	}
-	ptr := a.Allocator.Alloc(uint64(size), 16)
+	ptr := a.Allocator.Alloc(uint64(size), alignOf[T]())
 	return (*T)(ptr)
 }
 
@@ -39,6 +53,32 @@ func Ptr[T any](a *Arena, value T) *T {
 	return ptr
 }
 
+// AllocInit allocates a zeroed T in the arena, calls init to set it up in place and
+// returns the pointer. Unlike Ptr, large structs are never copied, which makes it the
+// natural constructor for structs embedding arena-backed containers.
+//
+// Example:
+//
+//	type Session struct { ID int; Events *arena.Vec[string] }
+//	s := arena.AllocInit(a, func(s *Session) {
+//	    s.ID = 7
+//	    s.Events = arena.NewVec[string](a)
+//	})
+func AllocInit[T any](a *Arena, init func(*T)) *T {
+	var zero T
+	size := unsafe.Sizeof(zero)
+	if size == 0 {
+		size = 1
+	}
+	ptr := a.Allocator.Alloc(uint64(size), alignOf[T]())
+	clear(unsafe.Slice((*byte)(ptr), size)) // memory reused after Reset is not zero
+	obj := (*T)(ptr)
+	if init != nil {
+		init(obj)
+	}
+	return obj
+}
+
 // MakeObject allocates and returns a pointer to a new instance of type T in the arena.
 // The object is zero-initialized. This is useful for creating struct instances without
 // heap allocation. The pointer remains valid until the arena is deleted or reset.
@@ -50,14 +90,11 @@ func Ptr[T any](a *Arena, value T) *T {
 //	node.Value = 42
 func MakeObject[T any](a *Arena) *T {
 	var zero T
-	var (
-		size  uintptr = unsafe.Sizeof(zero)
-		align uintptr = unsafe.Alignof(zero)
-	)
+	size := unsafe.Sizeof(zero)
 	if size == 0 {
 		size = 1
 	}
-	ptr := a.Allocator.Alloc(uint64(size), uint64(align))
+	ptr := a.Allocator.Alloc(uint64(size), alignOf[T]())
 	return (*T)(ptr)
 }
 
@@ -105,13 +142,37 @@ func MakeSlice[T any](a *Arena, length, capacity int) []T {
 	if uint64(capacity) > (1<<63)/uint64(size) {
 		panic("arena: slice allocation size overflow")
 	}
+	if a.sizeClasses {
+		capacity = sizeClass(capacity*int(size)) / int(size)
+	}
 	var (
-		ptr   = a.Allocator.Alloc(uint64(capacity)*uint64(size), 16)
+		ptr   = a.Allocator.Alloc(uint64(capacity)*uint64(size), alignOf[T]())
 		slice = unsafe.Slice((*T)(ptr), capacity)
 	)
 	return slice[:length]
 }
 
+// MakeSliceN allocates a slice of exactly n zero-initialized elements (len == cap == n).
+// It is shorthand for MakeSlice[T](a, n, n).
+//
+// Example:
+//
+//	counts := arena.MakeSliceN[int](a, 256)
+func MakeSliceN[T any](a *Arena, n int) []T {
+	return MakeSlice[T](a, n, n)
+}
+
+// MakeSliceCap allocates an empty slice with room for capacity elements (len == 0),
+// for building up with Append. Like MakeSlice, a zero capacity returns nil.
+//
+// Example:
+//
+//	names := arena.MakeSliceCap[string](a, 16)
+//	names = arena.Append(a, names, "alice")
+func MakeSliceCap[T any](a *Arena, capacity int) []T {
+	return MakeSlice[T](a, 0, capacity)
+}
+
 // Append appends elements to an arena-backed slice, growing it if necessary.
 // This function ensures that appended elements stay within arena memory and
 // don't cause heap allocations. When growing is required, the old slice backing
@@ -204,10 +265,39 @@ func (a *Arena) MakeString(s string) string {
 		return ""
 	}
 	ptr := a.Allocator.Alloc(uint64(len(s)), 1)
-	copy((*[1 << 30]byte)(ptr)[:len(s):len(s)], s)
+	data := unsafe.Slice((*byte)(ptr), len(s))
+	if len(s) <= SMALL_STRING_SIZE {
+		// Small strings: a byte loop beats the memmove call overhead
+		for i := range len(s) {
+			data[i] = s[i]
+		}
+	} else {
+		copy(data, s)
+	}
 	return unsafe.String((*byte)(ptr), len(s))
 }
 
+// MakeStringUnsafe returns s without copying when s already lives in this arena's memory,
+// for example a substring of a string produced by MakeString, Split or Join.
+// If s is not owned by the arena it falls back to MakeString.
+//
+// Aliasing contract: the returned string shares its bytes with s. It is only valid
+// for as long as the arena memory backing s, and must not outlive a Reset or Delete.
+//
+// Example:
+//
+//	line := a.MakeString("key=value")
+//	key := a.MakeStringUnsafe(line[:3]) // no copy, view into line
+func (a *Arena) MakeStringUnsafe(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	if a.Allocator.Owns(unsafe.Pointer(unsafe.StringData(s))) {
+		return s
+	}
+	return a.MakeString(s)
+}
+
 // CloneString returns a heap-allocated copy of an arena-backed string.
 // The returned string is independent of the arena lifecycle and can be safely
 // used after the arena is deleted. Use this when you need to preserve string
@@ -220,6 +310,15 @@ func CloneString(s string) string {
 	return string([]byte(s))
 }
 
+// cloneString returns v with its bytes copied into dst if T is string,
+// and v unchanged otherwise
+func cloneString[T any](dst *Arena, v T) T {
+	if str, ok := any(v).(string); ok {
+		return any(dst.MakeString(str)).(T)
+	}
+	return v
+}
+
 // DeleteObject marks an arena-allocated object for deletion.
 // This function should be used with allocators that support individual object deletion.
 // Note that not all allocator types support individual deletions.
@@ -0,0 +1,74 @@
+package arena
+
+// Optional holds either a value (Some) or nothing (None). It is a plain value type,
+// so it needs no arena allocation of its own, and adapts the (T, bool) results of
+// Vec, Map and SkipList for chaining via OptionalOf.
+//
+// Example:
+//
+//	port := arena.MapOptional(arena.OptionalOf(ports.Get("http")), strconv.Itoa).OrElse("8080")
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Some returns an Optional holding v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, ok: true}
+}
+
+// None returns an empty Optional
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// OptionalOf wraps a (value, found) pair such as the result of Map.Get or Vec.Get
+func OptionalOf[T any](v T, ok bool) Optional[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// Get returns the value and true, or the zero value and false if the Optional is empty
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.ok
+}
+
+// IsSome reports whether the Optional holds a value
+func (o Optional[T]) IsSome() bool {
+	return o.ok
+}
+
+// OrElse returns the value, or def if the Optional is empty
+func (o Optional[T]) OrElse(def T) T {
+	if !o.ok {
+		return def
+	}
+	return o.value
+}
+
+// Filter returns o if it holds a value satisfying keep, and None otherwise
+func (o Optional[T]) Filter(keep func(T) bool) Optional[T] {
+	if !o.ok || !keep(o.value) {
+		return None[T]()
+	}
+	return o
+}
+
+// MapOptional applies fn to the value of o, if any. It is a function rather than a
+// method because Go methods cannot introduce the result type parameter U.
+func MapOptional[T, U any](o Optional[T], fn func(T) U) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return Some(fn(o.value))
+}
+
+// FlatMapOptional applies fn, which may itself produce None, to the value of o, if any
+func FlatMapOptional[T, U any](o Optional[T], fn func(T) Optional[U]) Optional[U] {
+	if !o.ok {
+		return None[U]()
+	}
+	return fn(o.value)
+}
@@ -0,0 +1,8 @@
+//go:build arenadebug
+
+package arena
+
+// poisonEnabled turns on junk-filling of reclaimed memory.
+// Built with -tags arenadebug: Reset (and child rollback) fill the reclaimed region
+// with POISON_BYTE, and Alloc zeroes each block before handing it out again.
+const poisonEnabled = true
@@ -0,0 +1,6 @@
+//go:build !arenadebug
+
+package arena
+
+// poisonEnabled is false in release builds, compiling the poisoning paths out.
+const poisonEnabled = false
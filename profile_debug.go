@@ -0,0 +1,70 @@
+//go:build arenadebug
+
+package arena
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// packagePrefix prefixes the names of this package's functions and methods; frames
+// matching it are skipped so allocations are attributed to the caller's code
+var packagePrefix = reflect.TypeFor[Stats]().PkgPath() + "."
+
+// allocProfile attributes allocations to the first call site outside this package.
+// Built with -tags arenadebug: every Alloc walks the stack with runtime.Callers.
+type allocProfile struct {
+	mu    sync.Mutex
+	sites map[uintptr]*AllocSiteStat // keyed by the call site's program counter
+}
+
+// record charges an allocation of size bytes to the calling site
+func (p *allocProfile) record(size uint64) {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:]) // skip Callers, record and the allocator's Alloc
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) || !more {
+			p.add(frame, size)
+			return
+		}
+	}
+}
+
+// add charges size bytes to the site of frame
+func (p *allocProfile) add(frame runtime.Frame, size uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sites == nil {
+		p.sites = make(map[uintptr]*AllocSiteStat)
+	}
+	site := p.sites[frame.PC]
+	if site == nil {
+		site = &AllocSiteStat{Function: frame.Function, File: frame.File, Line: frame.Line}
+		p.sites[frame.PC] = site
+	}
+	site.Count++
+	site.Bytes += size
+}
+
+// snapshot returns a copy of the profile keyed by "file:line"
+func (p *allocProfile) snapshot() map[string]AllocSiteStat {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	profile := make(map[string]AllocSiteStat, len(p.sites))
+	for _, site := range p.sites {
+		key := fmt.Sprintf("%s:%d", site.File, site.Line)
+		merged := profile[key] // distinct PCs can share a line
+		merged.Function, merged.File, merged.Line = site.Function, site.File, site.Line
+		merged.Count += site.Count
+		merged.Bytes += site.Bytes
+		profile[key] = merged
+	}
+	return profile
+}
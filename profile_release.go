@@ -0,0 +1,13 @@
+//go:build !arenadebug
+
+package arena
+
+// allocProfile is empty in release builds: recording compiles to nothing and
+// AllocProfile returns nil.
+type allocProfile struct{}
+
+func (p *allocProfile) record(size uint64) {}
+
+func (p *allocProfile) snapshot() map[string]AllocSiteStat {
+	return nil
+}
@@ -0,0 +1,71 @@
+package arena
+
+// ROLLING_BASE is the polynomial base of RollingHash; arithmetic wraps mod 2^64
+const ROLLING_BASE = 1099511628211
+
+// RollingHash is a Rabin-Karp polynomial hash over a fixed-size window of bytes.
+// Sliding the window by one byte costs O(1) regardless of its size, which makes it
+// the building block for substring search, multi-pattern matching and content-defined
+// deduplication windows. Equal windows always hash equally; unequal windows collide
+// rarely, so callers must confirm a hash match by comparing the bytes.
+//
+// A new RollingHash starts from an all-zero "empty" state: feed the first window
+// with Roll(b, 0), since removing a zero byte is a no-op.
+//
+// Example:
+//
+//	h := arena.NewRollingHash(len(needle))
+//	want := h.Sum(needle)
+//	for i := range len(needle) {
+//	    h.Roll(haystack[i], 0)
+//	}
+//	// then Roll(haystack[i], haystack[i-len(needle)]) for each later i
+type RollingHash struct {
+	window int
+	pow    uint64 // ROLLING_BASE^(window-1), the weight of the outgoing byte
+	hash   uint64
+}
+
+// NewRollingHash creates a RollingHash over windows of windowSize bytes
+func NewRollingHash(windowSize int) *RollingHash {
+	if windowSize <= 0 {
+		panic("arena rolling hash: window size must be positive")
+	}
+	pow := uint64(1)
+	for range windowSize - 1 {
+		pow *= ROLLING_BASE
+	}
+	return &RollingHash{window: windowSize, pow: pow}
+}
+
+// Roll slides the window by one byte, adding in and removing out (the byte that
+// entered windowSize rolls ago), and returns the hash of the new window
+func (h *RollingHash) Roll(in, out byte) uint64 {
+	h.hash = (h.hash-uint64(out)*h.pow)*ROLLING_BASE + uint64(in)
+	return h.hash
+}
+
+// Value returns the hash of the current window
+func (h *RollingHash) Value() uint64 {
+	return h.hash
+}
+
+// Sum returns the hash of s computed from scratch, without changing the rolling
+// state. For a window-sized s it equals the value Roll reaches after s slides in.
+func (h *RollingHash) Sum(s string) uint64 {
+	var sum uint64
+	for i := range len(s) {
+		sum = sum*ROLLING_BASE + uint64(s[i])
+	}
+	return sum
+}
+
+// Reset returns h to the empty state so it can hash a new stream
+func (h *RollingHash) Reset() {
+	h.hash = 0
+}
+
+// WindowSize returns the number of bytes in the window
+func (h *RollingHash) WindowSize() int {
+	return h.window
+}
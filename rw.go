@@ -1,18 +1,38 @@
 package arena
 
-import "io"
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+)
 
 // Writer provides a way to write bytes to an arena-allocated buffer
 // without the byte array escaping to the heap.
 type Writer struct {
-	arena  *Arena
-	buffer []byte
-	offset int
+	arena   *Arena
+	buffer  []byte
+	offset  int
+	written int    // end of the furthest byte written, the limit for SeekTo
+	resets  uint64 // arena ResetCount when a WriterPool created the writer
 }
 
 // NewWriter creates a new Writer with an arena-allocated buffer.
 func NewWriter(a *Arena) *Writer {
-	buf := MakeSlice[byte](a, 0, 32)
+	return NewWriterSize(a, 32)
+}
+
+// NewWriterSize creates a new Writer whose buffer is pre-allocated with the given capacity.
+// Sizing the buffer up front avoids the repeated grows (each of which orphans the
+// previous buffer in a bump arena) when the final payload size is roughly known.
+// A capacity <= 0 uses the NewWriter default.
+func NewWriterSize(a *Arena, capacity int) *Writer {
+	if capacity <= 0 {
+		capacity = 32
+	}
+	buf := MakeSlice[byte](a, 0, capacity)
 	buf = buf[:cap(buf)] // set len to cap to allow writing
 	return &Writer{
 		arena:  a,
@@ -30,6 +50,7 @@ func (w *Writer) Write(p []byte) (n int, err error) {
 	}
 	copy(w.buffer[w.offset:], p)
 	w.offset = w.offset + len(p)
+	w.written = max(w.written, w.offset)
 	return len(p), nil
 }
 
@@ -41,6 +62,7 @@ func (w *Writer) WriteString(s string) (n int, err error) {
 	}
 	copy(w.buffer[w.offset:], s)
 	w.offset = w.offset + len(s)
+	w.written = max(w.written, w.offset)
 	return len(s), nil
 }
 
@@ -51,6 +73,7 @@ func (w *Writer) WriteByte(c byte) error {
 	}
 	w.buffer[w.offset] = c
 	w.offset = w.offset + 1
+	w.written = max(w.written, w.offset)
 	return nil
 }
 
@@ -70,9 +93,53 @@ func (w *Writer) Cap() int {
 	return cap(w.buffer)
 }
 
+// Arena returns the arena backing the writer, for allocating auxiliary data
+// with the same lifetime as the written bytes.
+func (w *Writer) Arena() *Arena {
+	return w.arena
+}
+
 // Reset resets the writer to be empty but retains the underlying buffer.
 func (w *Writer) Reset() {
-	w.offset = 0
+	w.offset, w.written = 0, 0
+}
+
+// Offset returns the current write position.
+// Save it before writing a frame so the frame can be discarded with Truncate
+// or revisited with SeekTo.
+func (w *Writer) Offset() int {
+	return w.offset
+}
+
+// Truncate discards all but the first n written bytes.
+// It panics if n is negative or greater than Len.
+func (w *Writer) Truncate(n int) {
+	if n < 0 || n > w.offset {
+		panic("arena writer: truncation out of range")
+	}
+	w.offset, w.written = n, n
+}
+
+// SeekTo moves the write position to n, which may be anywhere up to the furthest byte
+// written since the last Reset or Truncate. Bytes after the new position are kept, so
+// seeking back to backfill a header and then seeking forward to a saved Offset restores
+// the previously written data. Seeking further would expose unwritten arena memory.
+// It panics if n is negative or past the furthest byte written.
+func (w *Writer) SeekTo(n int) {
+	if n < 0 || n > w.written {
+		panic("arena writer: seek out of range")
+	}
+	w.offset = n
+}
+
+// WriteFrame writes p as a length-prefixed frame: a uvarint length followed by the payload.
+// It returns the total number of bytes written, including the prefix.
+func (w *Writer) WriteFrame(p []byte) (int, error) {
+	var temp [binary.MaxVarintLen64]byte
+	prefix := binary.AppendUvarint(temp[:0], uint64(len(p)))
+	w.Write(prefix)
+	w.Write(p)
+	return len(prefix) + len(p), nil
 }
 
 // grow ensures the buffer has at least the given capacity.
@@ -86,7 +153,7 @@ func (w *Writer) grow(size int) {
 	}
 	temp := MakeSlice[byte](w.arena, 0, capacity)
 	temp = temp[:cap(temp)]
-	copy(temp, w.buffer[:w.offset])
+	copy(temp, w.buffer)
 	DeleteSlice(w.arena, w.buffer)
 	w.buffer = temp
 }
@@ -121,7 +188,7 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 
 // Len returns the number of bytes remaining to be read.
 func (r *Reader) Len() int {
-	return len(r.buffer) - r.offset
+	return max(len(r.buffer)-r.offset, 0)
 }
 
 // Size returns the original length of the buffer.
@@ -129,7 +196,343 @@ func (r *Reader) Size() int {
 	return len(r.buffer)
 }
 
+// Arena returns the arena the reader was created with.
+func (r *Reader) Arena() *Arena {
+	return r.arena
+}
+
 // Reset resets the reader to the beginning of the buffer.
 func (r *Reader) Reset() {
 	r.offset = 0
 }
+
+// Pos returns the current read position.
+func (r *Reader) Pos() int {
+	return r.offset
+}
+
+// Remaining returns the unread bytes without consuming them.
+// The slice aliases the reader's buffer (zero-copy) and is only valid as long as
+// that buffer is; pass it to a sub-parser and then Seek past what it consumed.
+func (r *Reader) Remaining() []byte {
+	return r.buffer[min(r.offset, len(r.buffer)):]
+}
+
+// Consumed returns the bytes already read. Like Remaining, it aliases the buffer.
+func (r *Reader) Consumed() []byte {
+	return r.buffer[:min(r.offset, len(r.buffer))]
+}
+
+// Seek implements io.Seeker. Seeking beyond the end is allowed; reads then return io.EOF
+// and Remaining is empty.
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = int64(r.offset) + offset
+	case io.SeekEnd:
+		abs = int64(len(r.buffer)) + offset
+	default:
+		return 0, errors.New("arena reader: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("arena reader: negative position")
+	}
+	r.offset = int(abs)
+	return abs, nil
+}
+
+// ReadFrame reads a frame written by WriteFrame and returns its payload.
+// The payload aliases the reader's buffer (zero-copy). It returns io.EOF when no
+// bytes remain and io.ErrUnexpectedEOF if the frame is truncated, in which case the
+// read position is left unchanged.
+func (r *Reader) ReadFrame() ([]byte, error) {
+	if r.offset >= len(r.buffer) {
+		return nil, io.EOF
+	}
+	return r.readPrefixed()
+}
+
+// PrefixWriter is an io.Writer that inserts a prefix at the start of every line
+// written through it, e.g. to indent nested output or tag log lines with a request ID.
+// Lines may be split across Write calls; the prefix is written once, just before the
+// first byte of each line, so output ending in a newline gets no dangling prefix.
+// It adds no buffering of its own: bytes go straight to dst, typically a *Buffer or
+// *Writer in the arena.
+//
+// Example:
+//
+//	buf := arena.NewBuffer(a)
+//	pw := arena.NewPrefixWriter(buf, "[req-42] ")
+//	fmt.Fprintf(pw, "started\nuser=%s\n", user)
+//	// "[req-42] started\n[req-42] user=alice\n"
+type PrefixWriter struct {
+	dst    io.Writer
+	prefix string
+	start  bool // the next byte begins a line
+}
+
+// NewPrefixWriter creates a PrefixWriter that writes to dst, prefixing each line with prefix
+func NewPrefixWriter(dst io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{dst: dst, prefix: prefix, start: true}
+}
+
+// Write writes p to the destination, inserting the prefix at line starts.
+// The returned count covers bytes of p only, not inserted prefixes.
+func (w *PrefixWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		if w.start {
+			if _, err = io.WriteString(w.dst, w.prefix); err != nil {
+				return n, err
+			}
+			w.start = false
+		}
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line, w.start = p[:i+1], true
+		}
+		var m int
+		m, err = w.dst.Write(line)
+		n = n + m
+		if err != nil {
+			return n, err
+		}
+		p = p[len(line):]
+	}
+	return n, nil
+}
+
+// FlushWriter streams output through an arena Writer to a destination such as a
+// socket, flushing whenever the buffered bytes reach a threshold, so the arena holds
+// at most about threshold bytes of output instead of the whole payload. Writes of at
+// least threshold bytes bypass the buffer. Call Flush when done to send the remainder.
+// After a destination error, every Write and Flush returns that error.
+//
+// Example:
+//
+//	fw := arena.NewFlushWriter(arena.NewWriterSize(a, 64<<10), conn, 64<<10)
+//	for _, row := range rows {
+//	    fmt.Fprintf(fw, "%d,%s\n", row.ID, row.Name)
+//	}
+//	if err := fw.Flush(); err != nil { ... }
+type FlushWriter struct {
+	w         *Writer
+	dst       io.Writer
+	threshold int
+	err       error
+}
+
+// NewFlushWriter creates a FlushWriter that buffers in w and flushes to dst once
+// threshold bytes are buffered. A threshold <= 0 uses w's current capacity.
+func NewFlushWriter(w *Writer, dst io.Writer, threshold int) *FlushWriter {
+	if threshold <= 0 {
+		threshold = w.Cap()
+	}
+	return &FlushWriter{w: w, dst: dst, threshold: threshold}
+}
+
+// Write buffers p, flushing first if p would take the buffer past the threshold.
+func (f *FlushWriter) Write(p []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	if f.w.Len()+len(p) > f.threshold {
+		if err := f.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) >= f.threshold {
+		n, err := f.dst.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		f.err = err
+		return n, err
+	}
+	f.w.Write(p)
+	if f.w.Len() >= f.threshold {
+		if err := f.Flush(); err != nil {
+			return len(p), err // p was accepted into the buffer
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered bytes to the destination and resets the buffer, keeping
+// its arena memory for reuse.
+func (f *FlushWriter) Flush() error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.w.Len() == 0 {
+		return nil
+	}
+	n, err := f.dst.Write(f.w.Bytes())
+	if err == nil && n < f.w.Len() {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		f.err = err
+		return err
+	}
+	f.w.Reset()
+	return nil
+}
+
+// Buffered returns the number of bytes waiting to be flushed.
+func (f *FlushWriter) Buffered() int {
+	return f.w.Len()
+}
+
+// WriterPool recycles Writers and their arena buffers for per-request use, so an
+// arena that is not reset between requests doesn't accumulate a fresh buffer (and
+// its grows) for every request. It is safe for concurrent use.
+//
+// Contract: Put resets the writer. After Put, neither the writer nor any slice
+// obtained from its Bytes may be used, since the buffer is handed to the next Get.
+//
+// Writers created before the arena's last Reset are dropped by Get rather than
+// reused, since their buffers now overlap fresh allocations. Restore and Delete are
+// not tracked: discard the pool before rewinding the arena past its writers' buffers
+// or deleting it.
+//
+// Example:
+//
+//	pool := arena.NewWriterPool(a, 4096)
+//	w := pool.Get()
+//	w.Write(payload)
+//	send(w.Bytes())
+//	pool.Put(w)
+type WriterPool struct {
+	arena *Arena
+	size  int
+	pool  sync.Pool
+}
+
+// NewWriterPool creates a pool whose new Writers are allocated in a with the given capacity
+func NewWriterPool(a *Arena, capacity int) *WriterPool {
+	p := &WriterPool{arena: a, size: capacity}
+	p.pool.New = func() any {
+		return p.newWriter()
+	}
+	return p
+}
+
+// Get returns an empty Writer from the pool, allocating one if the pool is empty
+// or holds only writers from before the arena's last Reset
+func (p *WriterPool) Get() *Writer {
+	w := p.pool.Get().(*Writer)
+	if w.resets != p.arena.ResetCount() {
+		return p.newWriter()
+	}
+	return w
+}
+
+// newWriter allocates a Writer stamped with the arena's current ResetCount
+func (p *WriterPool) newWriter() *Writer {
+	w := NewWriterSize(p.arena, p.size)
+	w.resets = p.arena.ResetCount()
+	return w
+}
+
+// Put resets w and returns it to the pool
+func (p *WriterPool) Put(w *Writer) {
+	w.Reset()
+	p.pool.Put(w)
+}
+
+// ReaderPool recycles Reader structs, avoiding a heap allocation per NewReader in
+// hot paths. It is safe for concurrent use.
+//
+// Contract: Put detaches the reader from its data; the reader must not be used after Put.
+type ReaderPool struct {
+	arena *Arena
+	pool  sync.Pool
+}
+
+// NewReaderPool creates a pool of Readers associated with a
+func NewReaderPool(a *Arena) *ReaderPool {
+	p := &ReaderPool{arena: a}
+	p.pool.New = func() any {
+		return &Reader{arena: p.arena}
+	}
+	return p
+}
+
+// Get returns a Reader positioned at the start of data
+func (p *ReaderPool) Get(data []byte) *Reader {
+	r := p.pool.Get().(*Reader)
+	r.buffer = data
+	r.offset = 0
+	return r
+}
+
+// Put detaches r from its data and returns it to the pool
+func (p *ReaderPool) Put(r *Reader) {
+	r.buffer = nil
+	r.offset = 0
+	p.pool.Put(r)
+}
+
+// MultiReader reads sequentially across several Readers as one logical stream,
+// moving on to the next when one is exhausted, without copying the fragments into
+// one buffer. It returns io.EOF once all readers are exhausted.
+//
+// Example:
+//
+//	mr := arena.NewMultiReader(a, arena.NewReader(a, head), arena.NewReader(a, body))
+//	msg, err := io.ReadAll(mr)
+type MultiReader struct {
+	arena   *Arena
+	readers []*Reader
+}
+
+// NewMultiReader creates a MultiReader over the given readers, read in order.
+// The readers are consumed as the MultiReader is read.
+func NewMultiReader(a *Arena, readers ...*Reader) *MultiReader {
+	return &MultiReader{
+		arena:   a,
+		readers: slices.Clone(readers), // don't alias the caller's slice, which it may reuse
+	}
+}
+
+// Read reads up to len(p) bytes from the current reader, advancing past exhausted
+// and empty readers. A single Read never spans two readers.
+func (m *MultiReader) Read(p []byte) (n int, err error) {
+	for len(m.readers) > 0 {
+		if m.readers[0].Len() > 0 {
+			return m.readers[0].Read(p)
+		}
+		m.readers = m.readers[1:]
+	}
+	return 0, io.EOF
+}
+
+// ReadByte reads a single byte, advancing across reader boundaries.
+func (m *MultiReader) ReadByte() (byte, error) {
+	for len(m.readers) > 0 {
+		if r := m.readers[0]; r.Len() > 0 {
+			c := r.buffer[r.offset]
+			r.offset++
+			return c, nil
+		}
+		m.readers = m.readers[1:]
+	}
+	return 0, io.EOF
+}
+
+// Len returns the total number of unread bytes across all remaining readers.
+func (m *MultiReader) Len() int {
+	n := 0
+	for _, r := range m.readers {
+		n = n + r.Len()
+	}
+	return n
+}
+
+// Arena returns the arena the MultiReader was created with.
+func (m *MultiReader) Arena() *Arena {
+	return m.arena
+}
@@ -51,7 +51,8 @@ type SkipList[K ordered, V any] struct {
 	lock  sync.RWMutex
 }
 
-type Pair[K ordered, V any] struct {
+// Pair is a key-value pair returned by ordered snapshots and sequence collectors
+type Pair[K any, V any] struct {
 	Key   K
 	Value V
 }
@@ -65,7 +66,7 @@ type node[K ordered, V any] struct {
 
 func NewSkipList[K ordered, V any](a *Arena) *SkipList[K, V] {
 	// Allocate head node
-	head := (*node[K, V])(a.Allocator.Alloc(uint64(unsafe.Sizeof(node[K, V]{})), 16))
+	head := Alloc[node[K, V]](a)
 	head.level = DEFAULT_MAX_LEVEL
 	head.forward = MakeSlice[*node[K, V]](a, DEFAULT_MAX_LEVEL+1, DEFAULT_MAX_LEVEL+1)
 
@@ -94,6 +95,26 @@ func (sl *SkipList[K, V]) Search(key K) (V, bool) {
 	return *new(V), false
 }
 
+// SearchRef returns a pointer to the value stored for key, avoiding a copy of large values.
+// ⚠️ CAUTION: The pointer aliases the node in arena memory and is not protected by the
+// skip list's lock; it becomes invalid once the key is deleted or the list is Reset.
+func (sl *SkipList[K, V]) SearchRef(key K) (*V, bool) {
+	sl.lock.RLock()
+	defer sl.lock.RUnlock()
+
+	x := sl.head
+	for i := sl.level; i >= 0; i-- {
+		for x.forward[i] != nil && x.forward[i].key < key {
+			x = x.forward[i]
+		}
+	}
+	x = x.forward[0]
+	if x != nil && x.key == key {
+		return &x.value, true
+	}
+	return nil, false
+}
+
 // Insert adds or updates a key-value pair
 func (sl *SkipList[K, V]) Insert(key K, value V) {
 	sl.lock.Lock()
@@ -124,7 +145,7 @@ func (sl *SkipList[K, V]) Insert(key K, value V) {
 	}
 
 	// Allocate new node
-	n := (*node[K, V])(sl.arena.Allocator.Alloc(uint64(unsafe.Sizeof(node[K, V]{})), 16))
+	n := Alloc[node[K, V]](sl.arena)
 	n.key = key
 	n.value = value
 	n.level = level
@@ -182,6 +203,106 @@ func (sl *SkipList[K, V]) Range(f func(K, V) bool) {
 	}
 }
 
+// ScanFrom iterates in sorted order over the entries with key >= start, calling f for
+// at most limit entries (0 = unlimited) and stopping early if f returns false.
+// It is the building block for keyset pagination: resume the next page from just
+// after the last key seen.
+//
+// Example:
+//
+//	var last int
+//	sl.ScanFrom(cursor, 50, func(k int, v string) bool {
+//	    last = k
+//	    return true
+//	})
+func (sl *SkipList[K, V]) ScanFrom(start K, limit int, f func(K, V) bool) {
+	sl.lock.RLock()
+	defer sl.lock.RUnlock()
+
+	x := sl.ceiling(start)
+	for n := 0; x != nil && (limit <= 0 || n < limit); n++ {
+		if !f(x.key, x.value) {
+			return
+		}
+		x = x.forward[0]
+	}
+}
+
+// GetByRankRange iterates over count entries starting at the zero-based rank startRank,
+// in sorted order, passing each entry's rank to f and stopping early if f returns false.
+// It is positional pagination for ranked data ("positions 100-149"), as opposed to
+// the key-based pagination of ScanFrom. Ranks outside the list yield nothing.
+//
+// Nodes do not carry span counts, so positioning walks the bottom level and costs
+// O(startRank); only the walk over the page itself is proportional to count.
+//
+// Example:
+//
+//	sl.GetByRankRange(100, 50, func(rank int, k int, v string) bool {
+//	    fmt.Println(rank+1, k, v)
+//	    return true
+//	})
+func (sl *SkipList[K, V]) GetByRankRange(startRank, count int, f func(rank int, k K, v V) bool) {
+	if startRank < 0 || count <= 0 {
+		return
+	}
+	sl.lock.RLock()
+	defer sl.lock.RUnlock()
+
+	x := sl.head.forward[0]
+	for r := 0; x != nil && r < startRank; r++ {
+		x = x.forward[0]
+	}
+	for rank := startRank; x != nil && rank < startRank+count; rank++ {
+		if !f(rank, x.key, x.value) {
+			return
+		}
+		x = x.forward[0]
+	}
+}
+
+// ceiling returns the first node with key >= key, or nil; the caller must hold the lock
+func (sl *SkipList[K, V]) ceiling(key K) *node[K, V] {
+	x := sl.head
+	for i := sl.level; i >= 0; i-- {
+		for x.forward[i] != nil && x.forward[i].key < key {
+			x = x.forward[i]
+		}
+	}
+	return x.forward[0]
+}
+
+// AllFrom returns an iterator over the key-value pairs with key >= start in sorted order.
+// This can be used with Go 1.23+ range-over-func:
+//
+//	for key, val := range skiplist.AllFrom(cursor) {
+//	    // process key, val
+//	}
+func (sl *SkipList[K, V]) AllFrom(start K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.lock.RLock()
+		defer sl.lock.RUnlock()
+		for x := sl.ceiling(start); x != nil; x = x.forward[0] {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
+// AllRange returns an iterator over the key-value pairs with lo <= key < hi in sorted order.
+func (sl *SkipList[K, V]) AllRange(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.lock.RLock()
+		defer sl.lock.RUnlock()
+		for x := sl.ceiling(lo); x != nil && x.key < hi; x = x.forward[0] {
+			if !yield(x.key, x.value) {
+				return
+			}
+		}
+	}
+}
+
 // All returns an iterator over all key-value pairs in sorted order.
 // This can be used with Go 1.23+ range-over-func:
 //
@@ -202,6 +323,51 @@ func (sl *SkipList[K, V]) All() iter.Seq2[K, V] {
 	}
 }
 
+// Cursor is a pull-based, writer-friendly iterator over a SkipList in ascending key
+// order. Unlike All and Keys, which hold the read lock for the whole iteration, each
+// Next takes the lock only briefly and repositions after the last key returned, so
+// long scans don't starve writers.
+//
+// Snapshot semantics are weaker: keys inserted ahead of the cursor are seen, keys
+// deleted ahead of it are skipped, and changes behind it are not revisited. Keys are
+// always returned in strictly increasing order. Not safe for use by multiple goroutines.
+type Cursor[K ordered, V any] struct {
+	sl      *SkipList[K, V]
+	last    K
+	started bool
+}
+
+// Cursor returns a Cursor positioned before the smallest key
+//
+// Example:
+//
+//	c := sl.Cursor()
+//	for k, v, ok := c.Next(); ok; k, v, ok = c.Next() {
+//	    // process k, v; writers may run in between
+//	}
+func (sl *SkipList[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{sl: sl}
+}
+
+// Next returns the entry with the smallest key greater than the last key returned,
+// or false when there is none
+func (c *Cursor[K, V]) Next() (K, V, bool) {
+	c.sl.lock.RLock()
+	defer c.sl.lock.RUnlock()
+
+	var x *node[K, V]
+	if !c.started {
+		x = c.sl.head.forward[0]
+	} else if x = c.sl.ceiling(c.last); x != nil && x.key == c.last {
+		x = x.forward[0]
+	}
+	if x == nil {
+		return *new(K), *new(V), false
+	}
+	c.last, c.started = x.key, true
+	return x.key, x.value, true
+}
+
 // Keys returns an iterator over all keys in sorted order.
 // This can be used with Go 1.23+ range-over-func:
 //
@@ -265,6 +431,39 @@ func (sl *SkipList[K, V]) Reset() {
 	sl.level = 0
 }
 
+// EqualFunc reports whether sl and other hold the same keys in the same order, with
+// values equal under eq. It walks both lists in lockstep without copying and stops
+// at the first difference. Both read locks are held for the walk, taken in address
+// order so that concurrent a.EqualFunc(b) and b.EqualFunc(a) cannot deadlock.
+func (sl *SkipList[K, V]) EqualFunc(other *SkipList[K, V], eq func(a, b V) bool) bool {
+	if sl == other {
+		return true
+	}
+	first, second := sl, other
+	if uintptr(unsafe.Pointer(first)) > uintptr(unsafe.Pointer(second)) {
+		first, second = second, first
+	}
+	first.lock.RLock()
+	defer first.lock.RUnlock()
+	second.lock.RLock()
+	defer second.lock.RUnlock()
+
+	x, y := sl.head.forward[0], other.head.forward[0]
+	for x != nil && y != nil {
+		if x.key != y.key || !eq(x.value, y.value) {
+			return false
+		}
+		x, y = x.forward[0], y.forward[0]
+	}
+	return x == nil && y == nil
+}
+
+// SkipListEqual reports whether a and b hold the same key-value pairs, comparing
+// values with ==. See EqualFunc.
+func SkipListEqual[K ordered, V comparable](a, b *SkipList[K, V]) bool {
+	return a.EqualFunc(b, func(x, y V) bool { return x == y })
+}
+
 // Contains checks if a key exists
 func (sl *SkipList[K, V]) Contains(key K) bool {
 	_, ok := sl.Search(key)
@@ -340,3 +539,27 @@ func (sl *SkipList[K, V]) CloneSlice() []Pair[K, V] {
 	}
 	return result
 }
+
+// CloneInto copies all entries into a new skip list allocated in dst.
+// This promotes data from a short-lived arena into a longer-lived one without a
+// heap round-trip. String keys and values are copied into dst; other values are
+// copied shallowly, so pointers still refer to their original memory.
+// Use CloneSkipListDeepInto for values that are themselves arena-backed containers.
+func (sl *SkipList[K, V]) CloneInto(dst *Arena) *SkipList[K, V] {
+	return CloneSkipListDeepInto(sl, dst, func(v V, a *Arena) V { return cloneString(a, v) })
+}
+
+// CloneSkipListDeepInto copies all entries of src into a new skip list allocated in
+// dst, calling cloneVal to deep-copy each value into dst. String keys are copied into dst.
+func CloneSkipListDeepInto[K ordered, V any](src *SkipList[K, V], dst *Arena, cloneVal func(V, *Arena) V) *SkipList[K, V] {
+	src.lock.RLock()
+	defer src.lock.RUnlock()
+
+	clone := NewSkipList[K, V](dst)
+	x := src.head.forward[0]
+	for x != nil {
+		clone.Insert(cloneString(dst, x.key), cloneVal(x.value, dst))
+		x = x.forward[0]
+	}
+	return clone
+}
@@ -1,41 +1,262 @@
 package arena
 
 import (
+	"math/bits"
+	"sync"
 	"unsafe"
 )
 
+// SlabAllocator hands out fixed-size blocks carved from runs of mmap'd pages.
+// Blocks released with Remove go onto a free list and are reused before new blocks
+// are carved, which suits workloads that allocate and free many objects of one size.
+// When the current run is exhausted another run of the same size is mapped.
+//
+// Requests larger than a block (or aligned more strictly than blocks are) cannot be
+// served from a run; each gets a dedicated page run that Remove or Reset unmaps.
 type SlabAllocator struct {
 	blockSize uintptr
+	runSize   int
+	runs      []slabRun
+	current   int        // run being carved
+	offset    uintptr    // carve position in the current run
+	free      *slabBlock // blocks released by Remove
+	large     [][]byte   // dedicated runs for oversized requests
+	allocated uint64     // bytes in live blocks and oversized runs
+	peak      uint64     // highest value of allocated
+	profile   allocProfile
+	mtx       sync.Mutex
 }
 
+// slabRun is one mapped range of memory divided into blocks
+type slabRun struct {
+	mem  []byte
+	live []uint64 // bitset of allocated blocks
+}
+
+// slabBlock is the free-list link stored in the first bytes of each free block
+type slabBlock struct {
+	next *slabBlock
+}
+
+// NewSlabAllocator creates a slab allocator of blockSize-byte blocks (rounded up to a
+// multiple of 16) whose runs are totalBytes long, rounded up to whole pages.
 func NewSlabAllocator(blockSize, totalBytes int) *SlabAllocator {
 	if blockSize < 16 {
 		blockSize = 16
 	}
 	blockSize = (blockSize + 15) &^ 15
-	s := &SlabAllocator{blockSize: uintptr(blockSize)}
-	// dummy implementation, no actual allocation
+	s := &SlabAllocator{
+		blockSize: uintptr(blockSize),
+		runSize:   max(totalBytes, blockSize),
+	}
+	s.addRun()
 	return s
 }
 
+// Alloc returns a zeroed block for requests of up to blockSize bytes, reusing removed
+// blocks first. Blocks are aligned to the largest power of two dividing blockSize.
+// Note: Pointers returned by Alloc become invalid after Remove, Reset() or Delete().
 func (s *SlabAllocator) Alloc(size, align uint64) unsafe.Pointer {
-	// dummy
-	return nil
+	s.profile.record(size)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if uintptr(size) > s.blockSize || uintptr(align) > s.blockAlign() {
+		mem := MakePages(int(max(size, 1)))
+		s.large = append(s.large, mem)
+		s.grow(uint64(len(mem)))
+		return unsafe.Pointer(unsafe.SliceData(mem))
+	}
+
+	var ptr unsafe.Pointer
+	if s.free != nil {
+		blk := s.free
+		s.free = blk.next
+		ptr = unsafe.Pointer(blk)
+	} else {
+		if s.offset+s.blockSize > uintptr(len(s.runs[s.current].mem)) {
+			s.current++
+			if s.current == len(s.runs) {
+				s.addRun()
+			}
+			s.offset = 0
+		}
+		ptr = unsafe.Pointer(&s.runs[s.current].mem[s.offset])
+		s.offset += s.blockSize
+	}
+	r, i := s.blockOf(ptr)
+	r.live[i/64] |= 1 << (i % 64)
+	s.grow(uint64(s.blockSize))
+	clear(unsafe.Slice((*byte)(ptr), s.blockSize))
+	return ptr
 }
 
+// Remove puts the block starting at ptr back on the free list, or unmaps it if it was
+// an oversized allocation. Pointers that are not the start of a live block (interior
+// pointers, memory from elsewhere, blocks already removed) are ignored. In arenadebug
+// builds the freed block is poisoned, apart from the free-list link in its first word.
+func (s *SlabAllocator) Remove(ptr unsafe.Pointer) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if ptr == nil {
+		return
+	}
+	for i, mem := range s.large {
+		if unsafe.Pointer(unsafe.SliceData(mem)) == ptr {
+			ReleasePages(mem)
+			s.large = append(s.large[:i], s.large[i+1:]...)
+			s.allocated -= uint64(len(mem))
+			return
+		}
+	}
+	r, i := s.blockOf(ptr)
+	if r == nil || uintptr(ptr)-r.base() != i*s.blockSize || i >= r.blocks(s.blockSize) ||
+		r.live[i/64]&(1<<(i%64)) == 0 {
+		return
+	}
+	r.live[i/64] &^= 1 << (i % 64)
+	s.allocated -= uint64(s.blockSize)
+	if poisonEnabled {
+		block := unsafe.Slice((*byte)(ptr), s.blockSize)
+		for j := range block {
+			block[j] = POISON_BYTE
+		}
+	}
+	blk := (*slabBlock)(ptr)
+	blk.next = s.free
+	s.free = blk
+}
+
+// Reset frees every block and unmaps oversized allocations. The runs are kept and
+// carved again from the start.
+// Note: All previously allocated pointers become invalid and should not be used.
 func (s *SlabAllocator) Reset() {
-	// dummy
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for i := range s.runs {
+		r := &s.runs[i]
+		if poisonEnabled && i <= s.current {
+			end := len(r.mem)
+			if i == s.current {
+				end = int(s.offset)
+			}
+			for j := range r.mem[:end] {
+				r.mem[j] = POISON_BYTE
+			}
+		}
+		clear(r.live)
+	}
+	s.current, s.offset, s.free = 0, 0, nil
+	s.allocated = 0
+	s.releaseLarge()
 }
 
+// Delete frees all memory allocated by the allocator.
+// Note: All previously allocated pointers become invalid and should not be used.
 func (s *SlabAllocator) Delete() {
-	// dummy
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for _, r := range s.runs {
+		ReleasePages(r.mem)
+	}
+	s.runs = nil
+	s.current, s.offset, s.free = 0, 0, nil
+	s.allocated = 0
+	s.releaseLarge()
 }
 
-func (s *SlabAllocator) Remove(ptr unsafe.Pointer) {
-	// no op for slab allocator
+// Stats reports the bytes in live blocks and oversized allocations, the bytes mapped
+// across all runs and the high-water mark of allocated bytes. Oversized allocations
+// count as runs.
+func (s *SlabAllocator) Stats() Stats {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var reserved uint64
+	for _, r := range s.runs {
+		reserved += uint64(len(r.mem))
+	}
+	for _, mem := range s.large {
+		reserved += uint64(len(mem))
+	}
+	return Stats{
+		BytesAllocated: s.allocated,
+		BytesReserved:  reserved,
+		ChunkCount:     len(s.runs) + len(s.large),
+		HighWaterMark:  s.peak,
+	}
 }
 
+// Owns checks if the given pointer belongs to memory managed by this allocator.
 func (s *SlabAllocator) Owns(ptr unsafe.Pointer) bool {
-	// TODO: implement when slab allocator is fully implemented
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if ptr == nil {
+		return false
+	}
+	if r, _ := s.blockOf(ptr); r != nil {
+		return true
+	}
+	addr := uintptr(ptr)
+	for _, mem := range s.large {
+		if base := uintptr(unsafe.Pointer(unsafe.SliceData(mem))); addr >= base && addr < base+uintptr(len(mem)) {
+			return true
+		}
+	}
 	return false
 }
+
+// addRun maps another run and makes it the last one.
+// The caller must hold the lock (or own s exclusively).
+func (s *SlabAllocator) addRun() {
+	r := slabRun{mem: MakePages(s.runSize)}
+	r.live = make([]uint64, (r.blocks(s.blockSize)+63)/64)
+	s.runs = append(s.runs, r)
+}
+
+// blockAlign returns the alignment of every block: the largest power of two dividing
+// blockSize, capped at the page size that runs are aligned to
+func (s *SlabAllocator) blockAlign() uintptr {
+	return min(uintptr(1)<<bits.TrailingZeros64(uint64(s.blockSize)), uintptr(pagesize))
+}
+
+// blockOf returns the run containing ptr and the index of the block ptr falls in,
+// or nil if ptr is not inside any run
+func (s *SlabAllocator) blockOf(ptr unsafe.Pointer) (*slabRun, uintptr) {
+	addr := uintptr(ptr)
+	for i := range s.runs {
+		r := &s.runs[i]
+		if base := r.base(); addr >= base && addr < base+uintptr(len(r.mem)) {
+			return r, (addr - base) / s.blockSize
+		}
+	}
+	return nil, 0
+}
+
+// grow adds n bytes to the allocated total; the caller must hold the lock
+func (s *SlabAllocator) grow(n uint64) {
+	s.allocated += n
+	s.peak = max(s.peak, s.allocated)
+}
+
+// releaseLarge unmaps every oversized allocation; the caller must hold the lock
+func (s *SlabAllocator) releaseLarge() {
+	for _, mem := range s.large {
+		ReleasePages(mem)
+	}
+	s.large = nil
+}
+
+// blocks returns the number of whole blocks in the run
+func (r *slabRun) blocks(blockSize uintptr) uintptr {
+	return uintptr(len(r.mem)) / blockSize
+}
+
+// base returns the address of the run's first byte
+func (r *slabRun) base() uintptr {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(r.mem)))
+}
@@ -2,7 +2,11 @@ package arena
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"iter"
+	"strconv"
 	"unicode"
 	"unicode/utf8"
 )
@@ -22,6 +26,14 @@ func (s *Str) TrimSpace(str string) string {
 	return UnsafeString(bytes.TrimSpace(UnsafeBytes(str)))
 }
 
+// TrimSpaceUnicode removes leading and trailing Unicode whitespace (as defined by
+// unicode.IsSpace) without copying. It is the explicit counterpart of FieldsUnicode;
+// TrimSpace already falls back to unicode.IsSpace for non-ASCII input and gives the
+// same result, with a faster path for pure ASCII strings.
+func (s *Str) TrimSpaceUnicode(str string) string {
+	return UnsafeString(bytes.TrimFunc(UnsafeBytes(str), unicode.IsSpace))
+}
+
 // IsEmpty checks if the string is empty or contains only whitespace.
 func (s *Str) IsEmpty(str string) bool {
 	return len(bytes.TrimSpace(UnsafeBytes(str))) == 0
@@ -47,6 +59,34 @@ func (s *Str) Index(str, substr string) int {
 	return bytes.Index(UnsafeBytes(str), UnsafeBytes(substr))
 }
 
+// IndexRolling returns the index of the first occurrence of needle in haystack, or -1,
+// using a Rabin-Karp RollingHash. Index is usually faster for a single search; this
+// exists as a reference use of RollingHash and for callers that want its predictable
+// O(len(haystack)) behaviour.
+func (s *Str) IndexRolling(haystack, needle string) int {
+	n := len(needle)
+	switch {
+	case n == 0:
+		return 0
+	case n > len(haystack):
+		return -1
+	}
+	h := NewRollingHash(n)
+	want := h.Sum(needle)
+	for i := range n {
+		h.Roll(haystack[i], 0)
+	}
+	for i := n; ; i++ {
+		if h.Value() == want && haystack[i-n:i] == needle {
+			return i - n
+		}
+		if i == len(haystack) {
+			return -1
+		}
+		h.Roll(haystack[i], haystack[i-n])
+	}
+}
+
 // LastIndex returns the index of the last occurrence of substr in str, or -1 if not found, without copying.
 func (s *Str) LastIndex(str, substr string) int {
 	return bytes.LastIndex(UnsafeBytes(str), UnsafeBytes(substr))
@@ -77,62 +117,100 @@ func (s *Str) Compare(str, t string) int {
 	return bytes.Compare(UnsafeBytes(str), UnsafeBytes(t))
 }
 
-// ToLower converts the string to lowercase.
+// IsASCII reports whether str consists only of ASCII bytes (< 0x80).
+// Use it to choose a byte-wise fast path over rune decoding up front.
+// Long strings are checked eight bytes at a time.
+func (s *Str) IsASCII(str string) bool {
+	const highBits = 0x8080808080808080
+	i := 0
+	for ; i+8 <= len(str); i += 8 {
+		if binary.LittleEndian.Uint64(UnsafeBytes(str[i:i+8]))&highBits != 0 {
+			return false
+		}
+	}
+	for ; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// ToLower converts the string to lowercase, including non-ASCII letters ("CAFÉ" -> "café").
 // Returns the original string without allocation if already lowercase.
 func (s *Str) ToLower(str string) string {
-	// Fast path: check if already lowercase
+	return s.mapCase(str, 'A', 'Z', unicode.ToLower)
+}
+
+// ToUpper converts the string to uppercase, including non-ASCII letters ("café" -> "CAFÉ").
+// Returns the original string without allocation if already uppercase.
+func (s *Str) ToUpper(str string) string {
+	return s.mapCase(str, 'a', 'z', unicode.ToUpper)
+}
+
+// mapCase maps every rune of str with fn, copying into the arena only if a rune changes.
+// ASCII-only strings take a byte-wise fast path that flips the case of letters in lo..hi.
+// Invalid UTF-8 bytes are copied through unchanged.
+func (s *Str) mapCase(str string, lo, hi byte, fn func(rune) rune) string {
+	// Find the first byte that changes, noting whether anything is non-ASCII
 	var (
-		b       = UnsafeBytes(str)
-		convert = false
+		first = -1
+		ascii = true
 	)
-	for _, c := range b {
-		if c >= 'A' && c <= 'Z' {
-			convert = true
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c >= utf8.RuneSelf {
+			ascii = false
 			break
 		}
-	}
-	if !convert {
-		return str
+		if first < 0 && c >= lo && c <= hi {
+			first = i
+		}
 	}
 
-	// Convert using Buffer
-	buf := NewBuffer(s.arena)
-	for _, c := range b {
-		if c >= 'A' && c <= 'Z' {
-			buf.Append([]byte{c + 32})
-		} else {
-			buf.Append([]byte{c})
+	if ascii {
+		if first < 0 {
+			return str
+		}
+		buf := NewBufferSize(s.arena, len(str))
+		buf.AppendString(str)
+		b := buf.Bytes()
+		for i := first; i < len(b); i++ {
+			if c := b[i]; c >= lo && c <= hi {
+				b[i] = c ^ 0x20 // ASCII upper and lower case differ only in this bit
+			}
 		}
+		return buf.String()
 	}
-	return buf.String()
-}
 
-// ToUpper converts the string to uppercase.
-// Returns the original string without allocation if already uppercase.
-func (s *Str) ToUpper(str string) string {
-	// Fast path: check if already uppercase
-	var (
-		b       = UnsafeBytes(str)
-		convert = false
-	)
-	for _, c := range b {
-		if c >= 'a' && c <= 'z' {
-			convert = true
+	// Unicode path: find the first rune that changes
+	first = -1
+	for i, r := range str {
+		if r == utf8.RuneError {
+			continue
+		}
+		if fn(r) != r {
+			first = i
 			break
 		}
 	}
-	if !convert {
+	if first < 0 {
 		return str
 	}
-
-	// Convert using Buffer
-	buf := NewBuffer(s.arena)
-	for _, c := range b {
-		if c >= 'a' && c <= 'z' {
-			buf.Append([]byte{c - 32})
+	var (
+		buf     = NewBufferSize(s.arena, len(str))
+		runeBuf [utf8.UTFMax]byte
+	)
+	buf.AppendString(str[:first])
+	for i := first; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.Append(UnsafeBytes(str[i : i+1]))
 		} else {
-			buf.Append([]byte{c})
+			n := utf8.EncodeRune(runeBuf[:], fn(r))
+			buf.Append(runeBuf[:n])
 		}
+		i += size
 	}
 	return buf.String()
 }
@@ -181,18 +259,42 @@ func (s *Str) Title(str string) string {
 	return buf.String()
 }
 
+// Capitalize returns str with its first rune in upper case and the rest unchanged
+// (sentence case), unlike Title, which capitalizes every word.
+// It returns str itself, without allocating, when the first rune is already upper case.
+func (s *Str) Capitalize(str string) string {
+	return s.mapFirstRune(str, unicode.ToUpper)
+}
+
+// Uncapitalize returns str with its first rune in lower case and the rest unchanged,
+// e.g. for converting a Go field name to camelCase.
+// It returns str itself, without allocating, when the first rune is already lower case.
+func (s *Str) Uncapitalize(str string) string {
+	return s.mapFirstRune(str, unicode.ToLower)
+}
+
+// mapFirstRune applies fn to the first rune of str, copying into the arena only if it changes
+func (s *Str) mapFirstRune(str string, fn func(rune) rune) string {
+	r, size := utf8.DecodeRuneInString(str)
+	if size == 0 || r == utf8.RuneError && size == 1 {
+		return str
+	}
+	mapped := fn(r)
+	if mapped == r {
+		return str
+	}
+	var runeBuf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(runeBuf[:], mapped)
+	buf := NewBufferSize(s.arena, n+len(str)-size)
+	buf.Append(runeBuf[:n])
+	buf.AppendString(str[size:])
+	return buf.String()
+}
+
 // Split splits the string by separator and allocates the result in the arena.
 func (s *Str) Split(str, sep string) []string {
 	if sep == "" {
-		// Split into individual runes
-		var (
-			n     = utf8.RuneCountInString(str)
-			slice = MakeSlice[string](s.arena, 0, n)
-		)
-		for _, r := range str {
-			slice = Append(s.arena, slice, string(r))
-		}
-		return slice
+		return s.SplitRunes(str)
 	}
 
 	// Count occurrences to pre-allocate with exact capacity
@@ -220,6 +322,19 @@ func (s *Str) Split(str, sep string) []string {
 	return slice
 }
 
+// SplitRunes splits str into its UTF-8 sequences, one element per rune, like
+// strings.Split(str, ""). Each element is a zero-copy substring of str; only the
+// slice of elements is allocated, in the arena. Invalid bytes become one-byte elements.
+func (s *Str) SplitRunes(str string) []string {
+	slice := MakeSliceN[string](s.arena, utf8.RuneCountInString(str))
+	for i, n := 0, 0; i < len(str); n++ {
+		_, size := utf8.DecodeRuneInString(str[i:])
+		slice[n] = str[i : i+size]
+		i = i + size
+	}
+	return slice
+}
+
 // Join joins the elements with separator and allocates the result in the arena.
 func (s *Str) Join(elems []string, sep string) string {
 	if len(elems) == 0 {
@@ -246,6 +361,77 @@ func (s *Str) Join(elems []string, sep string) string {
 	return UnsafeString(data)
 }
 
+// TruncateBytes returns the longest prefix of str that fits in maxBytes bytes without
+// splitting a UTF-8 encoded rune, for byte-limited storage such as database columns
+// or protocol fields. The result is a zero-copy substring. A maxBytes below 1 returns
+// an empty string. Invalid bytes are treated as one-byte runes.
+func (s *Str) TruncateBytes(str string, maxBytes int) string {
+	if len(str) <= maxBytes {
+		return str
+	}
+	if maxBytes <= 0 {
+		return ""
+	}
+	// Back off to the start of the rune straddling the limit, if it is a valid one
+	i := maxBytes
+	for i > 0 && i > maxBytes-utf8.UTFMax && !utf8.RuneStart(str[i]) {
+		i--
+	}
+	if r, size := utf8.DecodeRuneInString(str[i:]); r == utf8.RuneError && size <= 1 || i+size <= maxBytes {
+		i = maxBytes
+	}
+	return str[:i]
+}
+
+const ellipsis = "…"
+
+// Abbreviate shortens str to at most maxRunes runes for display. Longer strings are
+// cut on a rune boundary after maxRunes-1 runes and suffixed with "…", allocated in
+// the arena; strings that already fit are returned unchanged without copying.
+// A maxRunes below 1 returns an empty string.
+func (s *Str) Abbreviate(str string, maxRunes int) string {
+	if maxRunes < 1 {
+		return ""
+	}
+	if utf8.RuneCountInString(str) <= maxRunes {
+		return str
+	}
+	return s.Join([]string{str[:runeOffset(str, maxRunes-1)], ellipsis}, "")
+}
+
+// AbbreviateMiddle shortens str to at most maxRunes runes by replacing the middle with
+// "…", keeping both ends visible, which suits paths and identifiers. Strings that
+// already fit are returned unchanged without copying.
+// A maxRunes below 1 returns an empty string.
+//
+// Example:
+//
+//	str.AbbreviateMiddle("/usr/local/lib/arena/arena.go", 15) // "/usr/lo…rena.go"
+func (s *Str) AbbreviateMiddle(str string, maxRunes int) string {
+	if maxRunes < 1 {
+		return ""
+	}
+	n := utf8.RuneCountInString(str)
+	if n <= maxRunes {
+		return str
+	}
+	keep := maxRunes - 1
+	head := runeOffset(str, keep-keep/2)
+	tail := runeOffset(str, n-keep/2)
+	return s.Join([]string{str[:head], str[tail:]}, ellipsis)
+}
+
+// runeOffset returns the byte offset of the n-th rune in str, or len(str)
+func runeOffset(str string, n int) int {
+	for i := range str {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(str)
+}
+
 // Fields splits the string on whitespace and allocates the result in the arena.
 func (s *Str) Fields(str string) []string {
 	// Fast path for empty string
@@ -292,6 +478,14 @@ func (s *Str) Fields(str string) []string {
 	return slice
 }
 
+// FieldsUnicode splits the string on Unicode whitespace (as defined by unicode.IsSpace)
+// and allocates the result in the arena. Unlike Fields, which only recognises ASCII
+// space, tab, newline and carriage return, it also splits on characters such as
+// U+00A0 (no-break space) and U+3000 (ideographic space).
+func (s *Str) FieldsUnicode(str string) []string {
+	return s.FieldsFunc(str, unicode.IsSpace)
+}
+
 // TrimPrefix removes the prefix from the string if present, without copying.
 func (s *Str) TrimPrefix(str, prefix string) string {
 	if s.HasPrefix(str, prefix) {
@@ -313,6 +507,102 @@ func (s *Str) Count(str, substr string) int {
 	return bytes.Count(UnsafeBytes(str), UnsafeBytes(substr))
 }
 
+// CountLines returns the number of lines in str, matching what Lines yields:
+// the number of newlines, plus one for a final line without a trailing newline.
+// An empty string has no lines.
+func (s *Str) CountLines(str string) int {
+	n := bytes.Count(UnsafeBytes(str), []byte{'\n'})
+	if len(str) > 0 && str[len(str)-1] != '\n' {
+		n = n + 1
+	}
+	return n
+}
+
+// CountRunes returns the number of UTF-8 encoded runes in str.
+// Invalid bytes are counted as one rune each, like utf8.RuneCountInString.
+func (s *Str) CountRunes(str string) int {
+	return utf8.RuneCountInString(str)
+}
+
+// Heredoc cleans up an indented multi-line literal: it drops one leading newline,
+// removes the leading whitespace common to all non-blank lines (the minimum indent,
+// compared byte-wise so tabs and spaces are not mixed up) and empties whitespace-only
+// lines. The result is allocated in the arena, or is a substring of str when nothing
+// but the leading newline needs removing.
+//
+// Example:
+//
+//	query := str.Heredoc(`
+//	    SELECT id, name
+//	    FROM users
+//	        WHERE active
+//	`)
+//	// "SELECT id, name\nFROM users\n    WHERE active\n"
+func (s *Str) Heredoc(str string) string {
+	if len(str) > 0 && str[0] == '\n' {
+		str = str[1:]
+	} else if len(str) > 1 && str[0] == '\r' && str[1] == '\n' {
+		str = str[2:]
+	}
+
+	var (
+		indent string
+		first  = true
+		blank  = false // a whitespace-only line must be emptied
+	)
+	for line := range s.Lines(str) {
+		content := trimLineEnding(line)
+		lead := leadingBlanks(content)
+		if lead == len(content) {
+			blank = blank || lead > 0
+			continue
+		}
+		if first {
+			indent, first = content[:lead], false
+			continue
+		}
+		n := 0
+		for n < len(indent) && n < lead && indent[n] == content[n] {
+			n++
+		}
+		indent = indent[:n]
+	}
+	if len(indent) == 0 && !blank {
+		return str
+	}
+
+	buf := NewBufferSize(s.arena, len(str))
+	for line := range s.Lines(str) {
+		content := trimLineEnding(line)
+		if leadingBlanks(content) == len(content) {
+			buf.AppendString(line[len(content):])
+			continue
+		}
+		buf.AppendString(line[len(indent):])
+	}
+	return buf.String()
+}
+
+// trimLineEnding strips a trailing "\n" or "\r\n" from line
+func trimLineEnding(line string) string {
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+	}
+	return line
+}
+
+// leadingBlanks returns the number of leading spaces and tabs in line
+func leadingBlanks(line string) int {
+	n := 0
+	for n < len(line) && (line[n] == ' ' || line[n] == '\t') {
+		n++
+	}
+	return n
+}
+
 // IndexByte returns the index of the first instance of byte c in str, or -1 if not found.
 func (s *Str) IndexByte(str string, c byte) int {
 	return bytes.IndexByte(UnsafeBytes(str), c)
@@ -323,6 +613,12 @@ func (s *Str) LastIndexByte(str string, c byte) int {
 	return bytes.LastIndexByte(UnsafeBytes(str), c)
 }
 
+// IndexRune returns the byte index of the first instance of rune r in str, or -1 if not found.
+// Multibyte runes are matched on whole encoded sequences, so r never matches inside another rune.
+func (s *Str) IndexRune(str string, r rune) int {
+	return bytes.IndexRune(UnsafeBytes(str), r)
+}
+
 // IndexAny returns the index of the first instance of any character from chars in str, or -1 if not found.
 func (s *Str) IndexAny(str, chars string) int {
 	return bytes.IndexAny(UnsafeBytes(str), chars)
@@ -344,11 +640,15 @@ func (s *Str) ContainsRune(str string, r rune) bool {
 }
 
 // Replace replaces the first n occurrences of old with new and allocates the result in the arena.
-// If n < 0, all occurrences are replaced.
+// If n < 0, all occurrences are replaced. If old is empty, it matches at the beginning
+// of the string and after each UTF-8 sequence, like strings.Replace.
 func (s *Str) Replace(str, old, new string, n int) string {
-	if n == 0 || old == "" {
+	if n == 0 {
 		return str
 	}
+	if old == "" {
+		return s.replaceEmpty(str, new, n)
+	}
 
 	buf := NewBuffer(s.arena)
 	var (
@@ -374,6 +674,25 @@ func (s *Str) Replace(str, old, new string, n int) string {
 	return buf.String()
 }
 
+// replaceEmpty inserts new before each UTF-8 sequence of str and at its end, at most n
+// times (all if n < 0)
+func (s *Str) replaceEmpty(str, new string, n int) string {
+	buf := NewBuffer(s.arena)
+	for i, count := 0, 0; n < 0 || count < n; count++ {
+		buf.AppendString(new)
+		if i == len(str) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(str[i:])
+		buf.AppendString(str[i : i+size])
+		i = i + size
+		if n > 0 && count+1 == n {
+			buf.AppendString(str[i:])
+		}
+	}
+	return buf.String()
+}
+
 // ReplaceAll replaces all occurrences of old with new and allocates the result in the arena.
 func (s *Str) ReplaceAll(str, old, new string) string {
 	return s.Replace(str, old, new, -1)
@@ -406,6 +725,22 @@ func (s *Str) Cut(str, sep string) (before, after string, found bool) {
 	return str[:i], str[i+len(sep):], true
 }
 
+// CutRune cuts str around the first instance of rune r, returning the text before and after r.
+// The found result reports whether r appears in str.
+// If r does not appear in str, CutRune returns str, "", false.
+func (s *Str) CutRune(str string, r rune) (before, after string, found bool) {
+	i := s.IndexRune(str, r)
+	if i < 0 {
+		return str, "", false
+	}
+	size := utf8.RuneLen(r)
+	if size < 0 {
+		// Invalid rune matches a single invalid byte
+		_, size = utf8.DecodeRuneInString(str[i:])
+	}
+	return str[:i], str[i+size:], true
+}
+
 // CutPrefix returns str without the provided leading prefix string and reports whether it found the prefix.
 // If str doesn't start with prefix, CutPrefix returns str, false.
 func (s *Str) CutPrefix(str, prefix string) (after string, found bool) {
@@ -424,6 +759,58 @@ func (s *Str) CutSuffix(str, suffix string) (before string, found bool) {
 	return str, false
 }
 
+// Between returns the text between the first open delimiter in str and the first close
+// delimiter after it, without copying. The found result is false if either delimiter is
+// missing. Delimiters are not nested: in "((a))" with "(" and ")" the result is "(a".
+//
+// Example:
+//
+//	v, ok := str.Between(`key="value"`, `"`, `"`) // "value", true
+func (s *Str) Between(str, open, close string) (between string, found bool) {
+	_, after, found := s.Cut(str, open)
+	if !found {
+		return "", false
+	}
+	between, _, found = s.Cut(after, close)
+	if !found {
+		return "", false
+	}
+	return between, true
+}
+
+// BetweenAll returns the text of every non-overlapping open...close pair in str, scanning
+// left to right as Between does and resuming after each close. The substrings alias str;
+// only the result slice is allocated in the arena. Returns nil if there is no match.
+//
+// Example:
+//
+//	str.BetweenAll("<b>one</b> <b>two</b>", "<b>", "</b>") // ["one", "two"]
+func (s *Str) BetweenAll(str, open, close string) []string {
+	var slice []string
+	for {
+		i := s.Index(str, open)
+		if i < 0 {
+			return slice
+		}
+		start := i + len(open)
+		j := s.Index(str[start:], close)
+		if j < 0 {
+			return slice
+		}
+		slice = Append(s.arena, slice, str[start:start+j])
+		// Resume after the close delimiter of this match
+		next := start + j + len(close)
+		if next == 0 {
+			// Empty delimiters and content: step one rune to make progress
+			if str == "" {
+				return slice
+			}
+			_, next = utf8.DecodeRuneInString(str)
+		}
+		str = str[next:]
+	}
+}
+
 // SplitN splits the string by separator with a maximum of n parts and allocates the result in the arena.
 // If n < 0, there is no limit on the number of parts.
 func (s *Str) SplitN(str, sep string, n int) []string {
@@ -461,6 +848,38 @@ func (s *Str) SplitAfterN(str, sep string, n int) []string {
 	return slice
 }
 
+// SplitAfterFunc splits str after each rune satisfying f, keeping that rune at the end
+// of its segment, e.g. splitting text into sentences that keep their punctuation.
+// Unlike SplitAfter, no empty segment follows a final match, and an empty str yields
+// no segments. Segments are zero-copy substrings; the slice is allocated in the arena.
+func (s *Str) SplitAfterFunc(str string, f func(rune) bool) []string {
+	// Count segments first
+	n := 0
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		i = i + size
+		if f(r) || i == len(str) {
+			n++
+		}
+	}
+	var (
+		slice = MakeSlice[string](s.arena, 0, n)
+		start = 0
+	)
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		i = i + size
+		if f(r) {
+			slice = append(slice, str[start:i])
+			start = i
+		}
+	}
+	if start < len(str) {
+		slice = append(slice, str[start:])
+	}
+	return slice
+}
+
 // Lines returns an iterator over the newline-terminated lines in the string str.
 // The lines yielded by the iterator include their terminating newlines.
 // If str is empty, the iterator yields no lines at all.
@@ -485,6 +904,43 @@ func (s *Str) Lines(str string) iter.Seq[string] {
 	}
 }
 
+// SplitIndices returns an iterator over the substrings of str separated by sep, as
+// Split would return them, paired with the byte offset of each substring in str.
+// It allocates nothing; the yielded strings are views into str.
+// If sep is empty, str is split after each UTF-8 sequence.
+//
+// Example:
+//
+//	for col, field := range str.SplitIndices("a,,bc", ",") {
+//	    fmt.Println(col, field) // 0 "a", 2 "", 3 "bc"
+//	}
+func (s *Str) SplitIndices(str, sep string) iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		if sep == "" {
+			for i := 0; i < len(str); {
+				_, size := utf8.DecodeRuneInString(str[i:])
+				if !yield(i, str[i:i+size]) {
+					return
+				}
+				i = i + size
+			}
+			return
+		}
+		start := 0
+		for {
+			idx := s.Index(str[start:], sep)
+			if idx < 0 {
+				yield(start, str[start:])
+				return
+			}
+			if !yield(start, str[start:start+idx]) {
+				return
+			}
+			start = start + idx + len(sep)
+		}
+	}
+}
+
 // Clone returns a copy of the string, allocated in the arena.
 func (s *Str) Clone(str string) string {
 	return s.arena.MakeString(str)
@@ -542,6 +998,51 @@ func (s *Str) ContainsFunc(str string, f func(rune) bool) bool {
 	return false
 }
 
+// Match reports whether str matches the wildcard pattern, where '*' matches any
+// sequence of runes (including none) and '?' matches exactly one rune. All other
+// pattern characters match themselves; there is no escaping or character classes.
+// Matching is iterative, remembering only the most recent '*', so it runs in
+// O(len(pattern)*len(str)) time at worst and never allocates - much cheaper than
+// regexp for simple filters.
+//
+// Example:
+//
+//	str.Match("*.log", "app.log")                // true
+//	str.Match("user_*_config", "user_42_config") // true
+//	str.Match("v?.?", "v1.2")                    // true
+func (s *Str) Match(pattern, str string) bool {
+	p, n := 0, 0
+	star, next := -1, 0 // position of the last '*' and where it would resume in str
+	for n < len(str) {
+		if p < len(pattern) {
+			switch c := pattern[p]; {
+			case c == '*':
+				star, next = p, n
+				p++
+				continue
+			case c == '?':
+				_, size := utf8.DecodeRuneInString(str[n:])
+				p, n = p+1, n+size
+				continue
+			case c == str[n]:
+				p, n = p+1, n+1
+				continue
+			}
+		}
+		if star < 0 {
+			return false
+		}
+		// Mismatch: let the last '*' absorb one more rune and retry from there
+		_, size := utf8.DecodeRuneInString(str[next:])
+		next += size
+		p, n = star+1, next
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
 // IndexFunc returns the index into str of the first Unicode code point satisfying f(c),
 // or -1 if none do.
 func (s *Str) IndexFunc(str string, f func(rune) bool) int {
@@ -555,13 +1056,15 @@ func (s *Str) IndexFunc(str string, f func(rune) bool) int {
 
 // LastIndexFunc returns the index into str of the last Unicode code point satisfying f(c),
 // or -1 if none do.
+// The returned index is the byte offset of the matching rune's first byte; invalid
+// bytes are passed to f as utf8.RuneError one byte at a time.
 func (s *Str) LastIndexFunc(str string, f func(rune) bool) int {
-	for i := len(str) - 1; i >= 0; i-- {
-		r, size := utf8.DecodeLastRuneInString(str[:i+1])
+	for i := len(str); i > 0; {
+		r, size := utf8.DecodeLastRuneInString(str[:i])
+		i = i - size
 		if f(r) {
-			return i - (size - 1)
+			return i
 		}
-		i = i - (size - 1)
 	}
 	return -1
 }
@@ -681,3 +1184,460 @@ func (s *Str) TrimRightFunc(str string, f func(rune) bool) string {
 	}
 	return ""
 }
+
+const lowerhex = "0123456789abcdef"
+
+// Quote returns a double-quoted Go string literal representing str, allocated in the arena.
+// Quotes, backslashes and control characters are escaped like strconv.Quote,
+// but printable Unicode is kept as-is and no heap memory is used.
+func (s *Str) Quote(str string) string {
+	buf := NewBuffer(s.arena)
+	buf.grow(len(str) + 2)
+	buf.Append([]byte{'"'})
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.Append([]byte{'\\', 'x', lowerhex[str[i]>>4], lowerhex[str[i]&0xF]})
+			i = i + 1
+			continue
+		}
+		appendEscapedRune(buf, r, '"')
+		i = i + size
+	}
+	buf.Append([]byte{'"'})
+	return buf.String()
+}
+
+// appendEscapedRune writes r to buf, escaping it if it is the quote character,
+// a backslash or not printable.
+func appendEscapedRune(buf *Buffer, r rune, quote byte) {
+	if r == rune(quote) || r == '\\' {
+		buf.Append([]byte{'\\', byte(r)})
+		return
+	}
+	if strconv.IsPrint(r) {
+		var temp [utf8.UTFMax]byte
+		n := utf8.EncodeRune(temp[:], r)
+		buf.Append(temp[:n])
+		return
+	}
+	switch r {
+	case '\a':
+		buf.Append([]byte{'\\', 'a'})
+	case '\b':
+		buf.Append([]byte{'\\', 'b'})
+	case '\f':
+		buf.Append([]byte{'\\', 'f'})
+	case '\n':
+		buf.Append([]byte{'\\', 'n'})
+	case '\r':
+		buf.Append([]byte{'\\', 'r'})
+	case '\t':
+		buf.Append([]byte{'\\', 't'})
+	case '\v':
+		buf.Append([]byte{'\\', 'v'})
+	default:
+		switch {
+		case r < ' ' || r == 0x7f:
+			buf.Append([]byte{'\\', 'x', lowerhex[byte(r)>>4], lowerhex[byte(r)&0xF]})
+		case r < 0x10000:
+			buf.Append([]byte{'\\', 'u'})
+			for shift := 12; shift >= 0; shift = shift - 4 {
+				buf.Append([]byte{lowerhex[r>>uint(shift)&0xF]})
+			}
+		default:
+			buf.Append([]byte{'\\', 'U'})
+			for shift := 28; shift >= 0; shift = shift - 4 {
+				buf.Append([]byte{lowerhex[r>>uint(shift)&0xF]})
+			}
+		}
+	}
+}
+
+// QuoteSingle returns str wrapped in single quotes for POSIX shells, allocated in the arena.
+// Each embedded single quote is written as a closing quote, an escaped quote and an
+// opening quote, so the result is safe to pass to sh.
+func (s *Str) QuoteSingle(str string) string {
+	buf := NewBuffer(s.arena)
+	buf.grow(len(str) + 2)
+	buf.Append([]byte{'\''})
+	for {
+		i := s.IndexByte(str, '\'')
+		if i < 0 {
+			buf.AppendString(str)
+			break
+		}
+		buf.AppendString(str[:i])
+		buf.AppendString(`'\''`)
+		str = str[i+1:]
+	}
+	buf.Append([]byte{'\''})
+	return buf.String()
+}
+
+// TrimQuotes removes one matching pair of surrounding double or single quotes
+// without copying. Strings that are not wrapped in a balanced pair of the same
+// quote character are returned unchanged. Escapes inside are left as-is; use
+// Unquote to interpret them.
+func (s *Str) TrimQuotes(str string) string {
+	if len(str) >= 2 {
+		if q := str[0]; (q == '"' || q == '\'') && str[len(str)-1] == q {
+			return str[1 : len(str)-1]
+		}
+	}
+	return str
+}
+
+// Unquote interprets str as a double-quoted or back-quoted Go string literal and
+// returns the string value it represents, the reverse of Quote.
+// Literals without escapes are returned without copying; otherwise the result is
+// allocated in the arena. Malformed input returns strconv.ErrSyntax.
+func (s *Str) Unquote(str string) (string, error) {
+	n := len(str)
+	if n < 2 || str[0] != str[n-1] {
+		return "", strconv.ErrSyntax
+	}
+	quote, body := str[0], str[1:n-1]
+
+	switch quote {
+	case '`':
+		if s.IndexByte(body, '`') >= 0 {
+			return "", strconv.ErrSyntax
+		}
+		return body, nil
+	case '"':
+	default:
+		return "", strconv.ErrSyntax
+	}
+
+	if s.IndexByte(body, '\n') >= 0 {
+		return "", strconv.ErrSyntax
+	}
+
+	// Fast path: nothing to unescape
+	if s.IndexByte(body, '\\') < 0 && s.IndexByte(body, '"') < 0 {
+		if utf8.ValidString(body) {
+			return body, nil
+		}
+	}
+
+	var (
+		buf  = NewBuffer(s.arena)
+		temp [utf8.UTFMax]byte
+	)
+	buf.grow(len(body))
+	for len(body) > 0 {
+		r, multibyte, tail, err := strconv.UnquoteChar(body, quote)
+		if err != nil {
+			return "", err
+		}
+		body = tail
+		if r < utf8.RuneSelf || !multibyte {
+			buf.Append([]byte{byte(r)})
+		} else {
+			n := utf8.EncodeRune(temp[:], r)
+			buf.Append(temp[:n])
+		}
+	}
+	return buf.String(), nil
+}
+
+// SplitMap parses key/value pairs such as "a=1,b=2" into an arena-backed Map.
+// str is split on pairSep and each pair is cut at the first kvSep; keys and values
+// are zero-copy substrings of str. A pair without kvSep maps to an empty value,
+// empty pairs (e.g. from trailing separators) are skipped and duplicate keys keep
+// the last value. An empty pairSep treats the whole string as a single pair.
+func (s *Str) SplitMap(str, pairSep, kvSep string) *Map[string, string] {
+	m := NewMap[string, string](s.arena)
+	for len(str) > 0 {
+		pair, rest, found := str, "", false
+		if pairSep != "" {
+			pair, rest, found = s.Cut(str, pairSep)
+		}
+		if pair != "" {
+			key, value, _ := s.Cut(pair, kvSep)
+			m.Set(key, value)
+		}
+		if !found {
+			break
+		}
+		str = rest
+	}
+	return m
+}
+
+// StripANSI removes ANSI terminal escape sequences (colors, cursor movement, OSC titles)
+// and allocates the cleaned result in the arena.
+// Returns the original string without allocation if it contains no escapes.
+// Incomplete sequences at the end of the string are dropped.
+func (s *Str) StripANSI(str string) string {
+	const esc = 0x1b
+	start := s.IndexByte(str, esc)
+	if start < 0 {
+		return str
+	}
+
+	buf := NewBuffer(s.arena)
+	buf.grow(len(str))
+	for start >= 0 {
+		buf.AppendString(str[:start])
+		str = str[start+1:]
+		str = str[ansiSequenceLen(str):]
+		start = s.IndexByte(str, esc)
+	}
+	buf.AppendString(str)
+	return buf.String()
+}
+
+// ansiSequenceLen returns the length of the escape sequence body following an ESC byte,
+// or len(str) if the sequence is incomplete.
+func ansiSequenceLen(str string) int {
+	if len(str) == 0 {
+		return 0
+	}
+	switch str[0] {
+	case '[':
+		// CSI: parameter and intermediate bytes, then a final byte in 0x40–0x7E
+		for i := 1; i < len(str); i++ {
+			if str[i] >= 0x40 && str[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']', 'P', 'X', '^', '_':
+		// OSC/DCS/SOS/PM/APC: string terminated by BEL or ESC \
+		for i := 1; i < len(str); i++ {
+			if str[i] == 0x07 {
+				return i + 1
+			}
+			if str[i] == 0x1b && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		// nF sequences: intermediate bytes 0x20–0x2F, then a final byte 0x30–0x7E
+		// (also covers two-byte sequences such as ESC 7 or ESC M)
+		for i := 0; i < len(str); i++ {
+			if str[i] >= 0x30 && str[i] <= 0x7e {
+				return i + 1
+			}
+			if str[i] < 0x20 || str[i] > 0x2f {
+				return i
+			}
+		}
+	}
+	return len(str)
+}
+
+// ParseInt interprets str in the given base (0, 2 to 36) and bit size (0 to 64)
+// and returns the corresponding value, like strconv.ParseInt.
+// It is safe on zero-copy views from Split/Fields: the input is never retained.
+// Short base-10 inputs take a dedicated fast path; everything else, including
+// all error cases, is delegated to strconv so results and errors match exactly.
+func (s *Str) ParseInt(str string, base, bitSize int) (int64, error) {
+	if base == 10 && bitSize >= 0 && bitSize <= 64 {
+		if n, ok := parseDecimal(str, bitSize); ok {
+			return n, nil
+		}
+	}
+	return strconv.ParseInt(str, base, bitSize)
+}
+
+// parseDecimal parses an optionally signed base-10 integer of up to 18 digits,
+// which cannot overflow int64. It reports false for anything it cannot parse
+// or that is out of range for bitSize, leaving the error to strconv.
+func parseDecimal(str string, bitSize int) (int64, bool) {
+	var (
+		digits = str
+		neg    = false
+	)
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		neg = digits[0] == '-'
+		digits = digits[1:]
+	}
+	if len(digits) == 0 || len(digits) > 18 {
+		return 0, false
+	}
+
+	var n int64
+	for i := 0; i < len(digits); i++ {
+		c := digits[i] - '0'
+		if c > 9 {
+			return 0, false
+		}
+		n = n*10 + int64(c)
+	}
+	if neg {
+		n = -n
+	}
+
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	if bitSize < 64 {
+		limit := int64(1) << (bitSize - 1)
+		if n >= limit || n < -limit {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// ParseFloat converts str to a floating-point number with the precision given by
+// bitSize (32 or 64), like strconv.ParseFloat.
+// It is safe on zero-copy views from Split/Fields: the input is never retained.
+func (s *Str) ParseFloat(str string, bitSize int) (float64, error) {
+	return strconv.ParseFloat(str, bitSize)
+}
+
+// HexEncode returns the lowercase hexadecimal encoding of data, allocated in the arena.
+// The output matches hex.EncodeToString.
+func (s *Str) HexEncode(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	out := MakeSliceN[byte](s.arena, hex.EncodedLen(len(data)))
+	hex.Encode(out, data)
+	return UnsafeString(out)
+}
+
+// HexDecode returns the bytes represented by the hexadecimal string str, allocated
+// in the arena. Errors match hex.DecodeString.
+func (s *Str) HexDecode(str string) ([]byte, error) {
+	if len(str) == 0 {
+		return nil, nil
+	}
+	out := MakeSliceN[byte](s.arena, hex.DecodedLen(len(str)))
+	n, err := hex.Decode(out, UnsafeBytes(str))
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// Base64Encode returns the standard, padded base64 encoding of data, allocated in
+// the arena. The output matches base64.StdEncoding.EncodeToString.
+func (s *Str) Base64Encode(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	out := MakeSliceN[byte](s.arena, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(out, data)
+	return UnsafeString(out)
+}
+
+// Base64Decode returns the bytes represented by the standard, padded base64 string
+// str, allocated in the arena. Errors match base64.StdEncoding.DecodeString.
+func (s *Str) Base64Decode(str string) ([]byte, error) {
+	if len(str) == 0 {
+		return nil, nil
+	}
+	out := MakeSliceN[byte](s.arena, base64.StdEncoding.DecodedLen(len(str)))
+	n, err := base64.StdEncoding.Decode(out, UnsafeBytes(str))
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// ContainsBytes checks if b contains sub. It is the []byte form of Contains,
+// for data that is already bytes (e.g. from network reads).
+func (s *Str) ContainsBytes(b, sub []byte) bool {
+	return bytes.Contains(b, sub)
+}
+
+// IndexBytes returns the index of the first occurrence of sub in b, or -1 if not found.
+func (s *Str) IndexBytes(b, sub []byte) int {
+	return bytes.Index(b, sub)
+}
+
+// HasPrefixBytes checks if b starts with prefix.
+func (s *Str) HasPrefixBytes(b, prefix []byte) bool {
+	return bytes.HasPrefix(b, prefix)
+}
+
+// HasSuffixBytes checks if b ends with suffix.
+func (s *Str) HasSuffixBytes(b, suffix []byte) bool {
+	return bytes.HasSuffix(b, suffix)
+}
+
+// TrimSpaceBytes removes leading and trailing whitespace from b without copying.
+func (s *Str) TrimSpaceBytes(b []byte) []byte {
+	return bytes.TrimSpace(b)
+}
+
+// SplitBytes splits b around each instance of sep, like Split. The parts are
+// subslices of b (capacity-limited so appending to one cannot overwrite the next);
+// only the slice holding them is allocated in the arena.
+// If sep is empty, b is split after each UTF-8 sequence.
+func (s *Str) SplitBytes(b, sep []byte) [][]byte {
+	if len(sep) == 0 {
+		var (
+			n     = utf8.RuneCount(b)
+			slice = MakeSlice[[]byte](s.arena, 0, n)
+		)
+		for len(b) > 0 {
+			_, size := utf8.DecodeRune(b)
+			slice = Append(s.arena, slice, b[:size:size])
+			b = b[size:]
+		}
+		return slice
+	}
+
+	var (
+		n     = bytes.Count(b, sep) + 1
+		slice = MakeSlice[[]byte](s.arena, 0, n)
+	)
+	for {
+		idx := bytes.Index(b, sep)
+		if idx < 0 {
+			slice = Append(s.arena, slice, b[:len(b):len(b)])
+			break
+		}
+		slice = Append(s.arena, slice, b[:idx:idx])
+		b = b[idx+len(sep):]
+	}
+	return slice
+}
+
+// FieldsBytes splits b on ASCII whitespace, like Fields. The fields are
+// capacity-limited subslices of b; only the slice holding them is allocated in the arena.
+func (s *Str) FieldsBytes(b []byte) [][]byte {
+	isSpace := func(c byte) bool {
+		return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	}
+
+	// Count fields first to pre-allocate with exact capacity
+	var (
+		n       = 0
+		inField = false
+	)
+	for _, c := range b {
+		wasInField := inField
+		inField = !isSpace(c)
+		if inField && !wasInField {
+			n = n + 1
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	var (
+		slice = MakeSlice[[]byte](s.arena, 0, n)
+		start = -1
+	)
+	for i, c := range b {
+		if start < 0 {
+			if !isSpace(c) {
+				start = i
+			}
+		} else if isSpace(c) {
+			slice = Append(s.arena, slice, b[start:i:i])
+			start = -1
+		}
+	}
+	if start >= 0 {
+		slice = Append(s.arena, slice, b[start:len(b):len(b)])
+	}
+	return slice
+}
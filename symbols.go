@@ -0,0 +1,83 @@
+package arena
+
+import (
+	"sync"
+)
+
+// SymbolTable interns names to small, dense integer IDs (0, 1, 2, ...) and back,
+// as used by lexers and interpreters to turn identifiers into compact tokens.
+// Names are copied into the arena on first sight.
+// Thread-safe: All operations are protected by an RWMutex.
+//
+// Example:
+//
+//	syms := arena.NewSymbolTable(a)
+//	x := syms.Intern("x")   // 0
+//	y := syms.Intern("y")   // 1
+//	syms.Intern("x")        // 0 again
+//	fmt.Println(syms.Name(y)) // "y"
+type SymbolTable struct {
+	mu    sync.RWMutex
+	arena *Arena
+	ids   *Map[string, int]
+	names *Vec[string]
+}
+
+// NewSymbolTable creates a new, empty SymbolTable
+func NewSymbolTable(a *Arena) *SymbolTable {
+	return &SymbolTable{
+		arena: a,
+		ids:   NewMap[string, int](a),
+		names: NewVec[string](a),
+	}
+}
+
+// Intern returns the ID for name, assigning the next ID on first sight
+func (st *SymbolTable) Intern(name string) int {
+	st.mu.RLock()
+	e := st.ids.find(name)
+	st.mu.RUnlock()
+	if e != nil {
+		return e.val
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	// Another goroutine may have interned name in the meantime
+	if e := st.ids.find(name); e != nil {
+		return e.val
+	}
+	id := st.names.Len()
+	name = st.arena.MakeString(name)
+	st.names.AppendOne(name)
+	st.ids.set(name, id)
+	return id
+}
+
+// Lookup returns the ID for name without interning it
+func (st *SymbolTable) Lookup(name string) (int, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	if e := st.ids.find(name); e != nil {
+		return e.val, true
+	}
+	return 0, false
+}
+
+// Name returns the arena-backed name for id, or "" if id was never assigned
+func (st *SymbolTable) Name(id int) string {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	name, _ := st.names.Get(id)
+	return name
+}
+
+// Len returns the number of interned names
+func (st *SymbolTable) Len() int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.names.Len()
+}
@@ -0,0 +1,82 @@
+package arena_test
+
+import (
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestAtomicCounter(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	arena.MakeSliceN[byte](a, 3) // misalign the bump offset
+	c := arena.NewAtomicCounter(a)
+	if uintptr(unsafe.Pointer(c))%arena.CACHE_LINE_SIZE != 0 {
+		t.Errorf("Expected cache-line-aligned counter, got %p", c)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if v := c.Load(); v != 16000 {
+		t.Errorf("Expected 16000, got %d", v)
+	}
+
+	c.Store(-5)
+	if v := c.Add(2); v != -3 {
+		t.Errorf("Expected -3 after Store(-5) and Add(2), got %d", v)
+	}
+}
+
+func TestAtomicCounters(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	arena.MakeSliceN[byte](a, 5)
+	shards := arena.NewAtomicCounters(a, 8)
+	if len(shards) != 8 {
+		t.Fatalf("Expected 8 counters, got %d", len(shards))
+	}
+	for i := range shards {
+		if addr := uintptr(unsafe.Pointer(&shards[i])); addr%arena.CACHE_LINE_SIZE != 0 {
+			t.Errorf("Counter %d not cache-line-aligned: %#x", i, addr)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				shards[g%len(shards)].Add(1)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	var total int64
+	for i := range shards {
+		if v := shards[i].Load(); v != 2000 {
+			t.Errorf("Expected 2000 in shard %d, got %d", i, v)
+		}
+		total += shards[i].Load()
+	}
+	if total != 16000 {
+		t.Errorf("Expected total 16000, got %d", total)
+	}
+	if arena.NewAtomicCounters(a, 0) != nil {
+		t.Error("Expected nil for zero counters")
+	}
+}
@@ -0,0 +1,258 @@
+package arena_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestBTreeInsertGet(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[int, string](a, 2)
+
+	bt.Insert(10, "ten")
+	bt.Insert(5, "five")
+	bt.Insert(15, "fifteen")
+	bt.Insert(3, "three")
+	bt.Insert(20, "twenty")
+
+	tests := []struct {
+		key      int
+		expected string
+		found    bool
+	}{
+		{10, "ten", true},
+		{5, "five", true},
+		{15, "fifteen", true},
+		{3, "three", true},
+		{20, "twenty", true},
+		{100, "", false},
+		{1, "", false},
+	}
+
+	for _, tt := range tests {
+		val, found := bt.Get(tt.key)
+		if found != tt.found {
+			t.Errorf("Get(%d): expected found=%v, got %v", tt.key, tt.found, found)
+		}
+		if found && val != tt.expected {
+			t.Errorf("Get(%d): expected %s, got %s", tt.key, tt.expected, val)
+		}
+	}
+}
+
+func TestBTreeUpdate(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[int, string](a, 2)
+	for i := range 10 {
+		bt.Insert(i, "old")
+	}
+	for i := range 10 {
+		bt.Insert(i, "new")
+	}
+
+	if bt.Len() != 10 {
+		t.Errorf("Expected length 10, got %d", bt.Len())
+	}
+	for i := range 10 {
+		if val, _ := bt.Get(i); val != "new" {
+			t.Errorf("Get(%d): expected 'new', got %s", i, val)
+		}
+	}
+}
+
+func TestBTreeDelete(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[int, string](a, 2)
+	bt.Insert(10, "ten")
+	bt.Insert(5, "five")
+	bt.Insert(15, "fifteen")
+
+	if !bt.Delete(5) {
+		t.Error("Delete(5) should return true")
+	}
+	if bt.Contains(5) {
+		t.Error("Key 5 should have been deleted")
+	}
+	if bt.Delete(100) {
+		t.Error("Delete(100) should return false for missing key")
+	}
+	if bt.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", bt.Len())
+	}
+	if !bt.Contains(10) || !bt.Contains(15) {
+		t.Error("Remaining keys should still be present")
+	}
+}
+
+func TestBTreeMinMax(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[int, string](a, 2)
+
+	if _, _, ok := bt.Min(); ok {
+		t.Error("Min on empty tree should return false")
+	}
+	if _, _, ok := bt.Max(); ok {
+		t.Error("Max on empty tree should return false")
+	}
+
+	for _, k := range []int{50, 20, 80, 10, 90, 30} {
+		bt.Insert(k, "v")
+	}
+
+	if k, _, ok := bt.Min(); !ok || k != 10 {
+		t.Errorf("Min: expected 10, got %d (ok=%v)", k, ok)
+	}
+	if k, _, ok := bt.Max(); !ok || k != 90 {
+		t.Errorf("Max: expected 90, got %d (ok=%v)", k, ok)
+	}
+}
+
+func TestBTreeRange(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[int, int](a, 2)
+	for _, k := range []int{5, 1, 4, 2, 3} {
+		bt.Insert(k, k*10)
+	}
+
+	var keys []int
+	bt.Range(func(k, v int) bool {
+		if v != k*10 {
+			t.Errorf("Range: key %d has value %d", k, v)
+		}
+		keys = append(keys, k)
+		return true
+	})
+	for i, k := range keys {
+		if k != i+1 {
+			t.Errorf("Range: expected key %d at position %d, got %d", i+1, i, k)
+		}
+	}
+
+	// Early termination
+	count := 0
+	bt.Range(func(k, v int) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("Range: expected to stop after 3, got %d", count)
+	}
+}
+
+func TestBTreeIterators(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[string, int](a, 3)
+	bt.Insert("c", 3)
+	bt.Insert("a", 1)
+	bt.Insert("b", 2)
+
+	var keys []string
+	for k := range bt.Keys() {
+		keys = append(keys, k)
+	}
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "c" {
+		t.Errorf("Keys: expected [a b c], got %v", keys)
+	}
+
+	var vals []int
+	for v := range bt.Values() {
+		vals = append(vals, v)
+	}
+	if len(vals) != 3 || vals[0] != 1 || vals[1] != 2 || vals[2] != 3 {
+		t.Errorf("Values: expected [1 2 3], got %v", vals)
+	}
+
+	pairs := bt.CloneSlice()
+	for i, p := range pairs {
+		if p.Key != keys[i] || p.Value != vals[i] {
+			t.Errorf("CloneSlice[%d]: expected %s=%d, got %s=%d", i, keys[i], vals[i], p.Key, p.Value)
+		}
+	}
+}
+
+func TestBTreeReset(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	bt := arena.NewBTree[int, int](a, 0)
+	for i := range 100 {
+		bt.Insert(i, i)
+	}
+	bt.Reset()
+	if bt.Len() != 0 {
+		t.Errorf("Expected length 0 after reset, got %d", bt.Len())
+	}
+	if bt.Contains(50) {
+		t.Error("Key 50 should not exist after reset")
+	}
+}
+
+func TestBTreeManyElements(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	const n = 5000
+	for _, degree := range []int{2, 3, 16} {
+		bt := arena.NewBTree[int, int](a, degree)
+		ref := make(map[int]int)
+		r := rand.New(rand.NewSource(int64(degree)))
+
+		for range n {
+			k := r.Intn(n)
+			bt.Insert(k, k*2)
+			ref[k] = k * 2
+		}
+		for k := range n {
+			if k%3 == 0 {
+				_, exists := ref[k]
+				if bt.Delete(k) != exists {
+					t.Fatalf("degree %d: Delete(%d) mismatch", degree, k)
+				}
+				delete(ref, k)
+			}
+		}
+
+		if bt.Len() != len(ref) {
+			t.Fatalf("degree %d: expected length %d, got %d", degree, len(ref), bt.Len())
+		}
+
+		prev, count := -1, 0
+		for k, v := range bt.All() {
+			if k <= prev {
+				t.Fatalf("degree %d: keys out of order: %d after %d", degree, k, prev)
+			}
+			if want, ok := ref[k]; !ok || v != want {
+				t.Fatalf("degree %d: unexpected entry %d=%d", degree, k, v)
+			}
+			prev = k
+			count++
+		}
+		if count != len(ref) {
+			t.Fatalf("degree %d: iterated %d entries, expected %d", degree, count, len(ref))
+		}
+
+		// Drain the tree completely
+		for k := range ref {
+			if !bt.Delete(k) {
+				t.Fatalf("degree %d: Delete(%d) failed while draining", degree, k)
+			}
+		}
+		if bt.Len() != 0 {
+			t.Fatalf("degree %d: expected empty tree, got %d", degree, bt.Len())
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package arena_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/thebagchi/arena-go"
+)
+
+// Every allocator must satisfy the interface Arena embeds
+var (
+	_ arena.Allocator = (*arena.BumpAllocator)(nil)
+	_ arena.Allocator = (*arena.SlabAllocator)(nil)
+	_ arena.Allocator = (*arena.BuddyAllocator)(nil)
+)
+
+func TestBuddyAllocator(t *testing.T) {
+	const chunk = 4096
+
+	t.Run("block sizes and alignment", func(t *testing.T) {
+		b := arena.NewBuddyAllocator(chunk, 4)
+		defer b.Delete()
+
+		tests := []struct {
+			size, align uint64
+			block       uintptr
+		}{
+			{1, 1, 16},
+			{16, 8, 16},
+			{17, 8, 32},
+			{100, 16, 128},
+			{24, 64, 64},
+			{chunk, 16, chunk},
+		}
+		for _, tt := range tests {
+			p := b.Alloc(tt.size, tt.align)
+			if p == nil {
+				t.Fatalf("Expected a block for size %d, got nil", tt.size)
+			}
+			if uintptr(p)%tt.block != 0 {
+				t.Errorf("Expected size %d align %d to be %d-aligned, got %p", tt.size, tt.align, tt.block, p)
+			}
+			if !b.Owns(p) {
+				t.Errorf("Expected allocator to own %p", p)
+			}
+		}
+		if b.Owns(nil) || b.Owns(unsafe.Pointer(new(int))) {
+			t.Errorf("Expected Owns to reject nil and heap pointers")
+		}
+	})
+
+	t.Run("coalesce on Remove", func(t *testing.T) {
+		b := arena.NewBuddyAllocator(chunk, 1)
+		defer b.Delete()
+
+		// Carve the chunk into 16-byte blocks, then free them in a scrambled order
+		var ptrs []unsafe.Pointer
+		for range chunk / 16 {
+			ptrs = append(ptrs, b.Alloc(16, 16))
+		}
+		base := ptrs[0]
+		rand.New(rand.NewSource(1)).Shuffle(len(ptrs), func(i, j int) { ptrs[i], ptrs[j] = ptrs[j], ptrs[i] })
+		for _, p := range ptrs {
+			b.Remove(p)
+		}
+		// Fully merged: the whole chunk is available again as one block
+		if p := b.Alloc(chunk, 16); p != base {
+			t.Errorf("Expected whole chunk at %p after coalescing, got %p", base, p)
+		}
+	})
+
+	t.Run("Remove ignores foreign and interior pointers", func(t *testing.T) {
+		b := arena.NewBuddyAllocator(chunk, 1)
+		defer b.Delete()
+
+		p := b.Alloc(64, 16)
+		b.Remove(unsafe.Add(p, 16))
+		b.Remove(unsafe.Pointer(new(int)))
+		b.Remove(nil)
+		q := b.Alloc(64, 16)
+		if q == p {
+			t.Errorf("Expected live block to survive bogus Removes")
+		}
+		b.Remove(p)
+		b.Remove(p) // double free is ignored
+		if r := b.Alloc(64, 16); r != p {
+			t.Errorf("Expected freed block %p to be reused, got %p", p, r)
+		}
+	})
+
+	t.Run("reused memory is zeroed", func(t *testing.T) {
+		b := arena.NewBuddyAllocator(chunk, 1)
+		defer b.Delete()
+
+		p := b.Alloc(256, 16)
+		s := unsafe.Slice((*byte)(p), 256)
+		for i := range s {
+			s[i] = 0xAB
+		}
+		b.Remove(p)
+		q := b.Alloc(256, 16)
+		for i, c := range unsafe.Slice((*byte)(q), 256) {
+			if c != 0 {
+				t.Fatalf("Expected zeroed memory at %d, got 0x%X", i, c)
+			}
+		}
+	})
+
+	t.Run("grows and Reset reclaims", func(t *testing.T) {
+		b := arena.NewBuddyAllocator(chunk, 1)
+		defer b.Delete()
+
+		first := b.Alloc(chunk, 16)
+		second := b.Alloc(chunk, 16) // needs a new region
+		big := b.Alloc(4*chunk, 16)  // larger than a chunk: dedicated region
+		for _, p := range []unsafe.Pointer{first, second, big} {
+			if p == nil || !b.Owns(p) {
+				t.Fatalf("Expected owned blocks after growth, got %p", p)
+			}
+		}
+		if second == first {
+			t.Errorf("Expected distinct blocks")
+		}
+		if uintptr(big)%chunk != 0 {
+			t.Errorf("Expected oversized block to be page-aligned, got %p", big)
+		}
+
+		b.Reset()
+		if p := b.Alloc(chunk, 16); p != first && p != second {
+			t.Errorf("Expected Reset to reclaim existing chunks, got %p", p)
+		}
+		if !b.Owns(big) {
+			t.Errorf("Expected regions to be kept across Reset")
+		}
+	})
+
+	t.Run("random alloc and remove never overlap", func(t *testing.T) {
+		b := arena.NewBuddyAllocator(chunk, 2)
+		defer b.Delete()
+
+		type block struct {
+			ptr  unsafe.Pointer
+			size int
+			tag  byte
+		}
+		var (
+			r    = rand.New(rand.NewSource(42))
+			live []block
+		)
+		for i := range 5000 {
+			if len(live) > 0 && r.Intn(3) == 0 {
+				k := r.Intn(len(live))
+				blk := live[k]
+				for j, c := range unsafe.Slice((*byte)(blk.ptr), blk.size) {
+					if c != blk.tag {
+						t.Fatalf("Block %p overwritten at %d: expected 0x%X, got 0x%X", blk.ptr, j, blk.tag, c)
+					}
+				}
+				b.Remove(blk.ptr)
+				live = append(live[:k], live[k+1:]...)
+				continue
+			}
+			size := 1 + r.Intn(2*chunk)
+			blk := block{ptr: b.Alloc(uint64(size), 16), size: size, tag: byte(i)}
+			s := unsafe.Slice((*byte)(blk.ptr), size)
+			for j := range s {
+				s[j] = blk.tag
+			}
+			live = append(live, blk)
+		}
+	})
+}
+
+func TestBuddyArena(t *testing.T) {
+	a := arena.New(4, arena.BUDDY)
+	defer a.Delete()
+
+	m := arena.NewMap[string, int](a)
+	v := arena.NewVec[int](a)
+	for i := range 1000 {
+		m.Set(a.MakeString("key"+strconv.Itoa(i)), i)
+		v.Push(i)
+	}
+	if m.Len() != 1000 || v.Len() != 1000 {
+		t.Fatalf("Expected 1000 entries, got map %d vec %d", m.Len(), v.Len())
+	}
+	for i := range 1000 {
+		if got, ok := m.Get("key" + strconv.Itoa(i)); !ok || got != i {
+			t.Fatalf("Expected key%d=%d, got %d (ok=%v)", i, i, got, ok)
+		}
+		if got, _ := v.Get(i); got != i {
+			t.Fatalf("Expected v[%d]=%d, got %d", i, i, got)
+		}
+	}
+	if !arena.OwnsSlice(a, v.Slice()) {
+		t.Errorf("Expected Vec backing to be owned by the BUDDY arena")
+	}
+
+	a.Reset()
+	s := arena.MakeSliceN[int](a, 100)
+	for i, x := range s {
+		if x != 0 {
+			t.Fatalf("Expected zeroed slice after Reset, got %d at %d", x, i)
+		}
+	}
+	if s := a.String(); s != "Arena{type=BUDDY}" {
+		t.Errorf("Expected Arena{type=BUDDY}, got %q", s)
+	}
+}
+
+func TestBuddyOwns(t *testing.T) {
+	b := arena.NewBuddyAllocator(4096, 2)
+	p := b.Alloc(16, 16)
+	tests := []struct {
+		name string
+		ptr  unsafe.Pointer
+		want bool
+	}{
+		{"allocated block", p, true},
+		{"interior pointer", unsafe.Add(p, 8), true},
+		{"free memory in region", unsafe.Add(p, 4096), true},
+		{"last byte of region", unsafe.Add(p, 2*4096-1), true},
+		{"past the region", unsafe.Add(p, 2*4096), false},
+		{"heap pointer", unsafe.Pointer(new(int)), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Owns(tt.ptr); got != tt.want {
+				t.Errorf("Expected Owns=%v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	b.Delete()
+	if b.Owns(p) {
+		t.Errorf("Expected Owns to be false after Delete")
+	}
+}
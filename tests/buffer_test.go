@@ -0,0 +1,115 @@
+package arena_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestBufferEqual(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	buf := arena.NewBufferString(a, "\x89PNG\r\nrest")
+
+	if !buf.Equal([]byte("\x89PNG\r\nrest")) || !buf.EqualString("\x89PNG\r\nrest") {
+		t.Error("Equal/EqualString should match identical content")
+	}
+	if buf.Equal([]byte("\x89PNG")) || buf.EqualString("\x89PNG\r\nresT") {
+		t.Error("Equal/EqualString should not match differing content")
+	}
+	if !buf.HasPrefix([]byte("\x89PNG")) {
+		t.Error("HasPrefix should match magic bytes")
+	}
+	if buf.HasPrefix([]byte("GIF8")) {
+		t.Error("HasPrefix should not match a different prefix")
+	}
+
+	empty := arena.NewBuffer(a)
+	if !empty.Equal(nil) || !empty.EqualString("") || !empty.HasPrefix(nil) {
+		t.Error("Empty buffer should equal empty content")
+	}
+}
+
+func TestNewBufferSize(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	buf := arena.NewBufferSize(a, 100)
+	if buf.Cap() != 100 || buf.Len() != 0 {
+		t.Fatalf("Expected len 0 cap 100, got len %d cap %d", buf.Len(), buf.Cap())
+	}
+
+	for range 10 {
+		buf.AppendString("0123456789")
+		if buf.Cap() != 100 {
+			t.Fatalf("Buffer regrew to cap %d at len %d", buf.Cap(), buf.Len())
+		}
+	}
+	if buf.Len() != 100 {
+		t.Errorf("Expected len 100, got %d", buf.Len())
+	}
+
+	// Exceeding the hint still works by growing
+	buf.AppendString("!")
+	if buf.Len() != 101 || buf.Cap() < 101 {
+		t.Errorf("Expected growth past hint, got len %d cap %d", buf.Len(), buf.Cap())
+	}
+
+	defer func() {
+		if r := recover(); r != "arena buffer: negative capacity" {
+			t.Errorf("Expected negative capacity panic, got %v", r)
+		}
+	}()
+	arena.NewBufferSize(a, -1)
+}
+
+func TestBufferWriteRepeated(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	buf := arena.NewBufferString(a, "[")
+	buf.WriteRepeated('-', 1000)
+	buf.WriteRepeated('x', 0)
+	buf.AppendString("]")
+	if want := "[" + strings.Repeat("-", 1000) + "]"; buf.String() != want {
+		t.Errorf("WriteRepeated: expected %d bytes of padding, got %q", 1000, buf.String())
+	}
+
+	buf = arena.NewBuffer(a)
+	buf.WriteRepeatedString("ab|", 333)
+	buf.WriteRepeatedString("", 10)
+	buf.WriteRepeatedString("zz", 0)
+	if want := strings.Repeat("ab|", 333); buf.String() != want {
+		t.Errorf("WriteRepeatedString: expected %d bytes, got %d", len(want), buf.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a negative repeat count")
+		}
+	}()
+	buf.WriteRepeated(' ', -1)
+}
+
+func BenchmarkBufferWriteRepeated(b *testing.B) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	buf := arena.NewBufferSize(a, 4096)
+	b.Run("WriteRepeatedString", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			buf.WriteRepeatedString("  ", 1024)
+		}
+	})
+	b.Run("AppendStringLoop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			buf.Reset()
+			for j := 0; j < 1024; j++ {
+				buf.AppendString("  ")
+			}
+		}
+	})
+}
@@ -154,3 +154,39 @@ func BenchmarkHeapAllocatorStress(b *testing.B) {
 		runtime.GC()
 	}
 }
+
+func BenchmarkBumpMakeStringSmall(b *testing.B) {
+	a := arena.New(1000, arena.BUMP)
+	str := "key"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%100000 == 0 {
+			a.Reset()
+		}
+		s := a.MakeString(str)
+		_ = s
+	}
+}
+
+func BenchmarkBumpMakeStringUnsafe(b *testing.B) {
+	a := arena.New(1000, arena.BUMP)
+	source := a.MakeString("benchmark string for allocation")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := a.MakeStringUnsafe(source[10:16])
+		_ = s
+	}
+}
+
+func BenchmarkBumpMakeStringCopy(b *testing.B) {
+	a := arena.New(1000, arena.BUMP)
+	source := a.MakeString("benchmark string for allocation")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%100000 == 0 {
+			a.Reset()
+		}
+		s := a.MakeString(source[10:16])
+		_ = s
+	}
+}
@@ -1,6 +1,9 @@
 package arena_test
 
 import (
+	"fmt"
+	"strings"
+	"syscall"
 	"testing"
 
 	"github.com/thebagchi/arena-go"
@@ -115,6 +118,51 @@ func TestBumpAllocatorVariousSizes(t *testing.T) {
 	}
 }
 
+func TestBumpOwnsEveryChunk(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	// Each page-sized slice lands in a chunk of its own
+	var slices [][]byte
+	for range 8 {
+		slices = append(slices, arena.MakeSlice[byte](a, 4096, 4096))
+	}
+	for i, s := range slices {
+		if !arena.OwnsSlice(a, s) || !arena.OwnsPtr(a, &s[len(s)-1]) {
+			t.Errorf("Expected arena to own slice %d", i)
+		}
+	}
+	if arena.OwnsPtr(a, new(int)) {
+		t.Errorf("Expected arena not to own a heap pointer")
+	}
+}
+
+func TestBumpReuseSmallChunkAfterReset(t *testing.T) {
+	page := syscall.Getpagesize()
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	// Leave a spare page-sized second chunk behind
+	arena.MakeSlice[byte](a, page, page)
+	arena.MakeSlice[byte](a, page, page)
+	a.Reset()
+
+	// A request larger than the spare chunk must not be placed in it
+	first := arena.MakeSlice[byte](a, page, page)
+	big := arena.MakeSlice[byte](a, 2*page, 2*page)
+	for i := range big {
+		big[i] = 0xAB
+	}
+	if !arena.OwnsSlice(a, big) || !arena.OwnsPtr(a, &big[len(big)-1]) {
+		t.Errorf("Expected the whole oversized slice to lie in an arena chunk")
+	}
+	for i, c := range first {
+		if c != 0 {
+			t.Fatalf("Expected the earlier slice untouched, got 0x%X at %d", c, i)
+		}
+	}
+}
+
 func TestBumpAllocatorGrow(t *testing.T) {
 	// Create a small arena with only 1 page (typically 4096 bytes)
 	a := arena.New(1, arena.BUMP)
@@ -151,3 +199,431 @@ func TestBumpAllocatorGrow(t *testing.T) {
 		t.Fatalf("anotherPtr: got %d, expected 999999", *anotherPtr)
 	}
 }
+
+func TestBumpChildArena(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	parent := arena.Ptr(a, 42)
+
+	child := a.Child()
+	first := arena.Ptr(child, 7)
+	if !arena.OwnsPtr(a, first) {
+		t.Error("Child allocations should come from the parent's pages")
+	}
+	for i := range 1000 {
+		arena.Ptr(child, i) // spill into further chunks
+	}
+	child.Delete()
+
+	if *parent != 42 {
+		t.Errorf("Parent allocation should survive child deletion, got %d", *parent)
+	}
+
+	// The child's space is reclaimed: the next parent allocation reuses it
+	reused := arena.Ptr(a, 99)
+	if reused != first {
+		t.Errorf("Expected parent to reuse child space at %p, got %p", first, reused)
+	}
+
+	// Nested children rewind to their own creation point
+	outer := a.Child()
+	arena.Ptr(outer, 1)
+	inner := outer.Child()
+	innerPtr := arena.Ptr(inner, 2)
+	inner.Delete()
+	if again := arena.Ptr(outer, 3); again != innerPtr {
+		t.Errorf("Expected outer child to reuse inner child space at %p, got %p", innerPtr, again)
+	}
+	outer.Delete()
+
+	// A child allocates with the parent's size-class setting
+	a.UseSizeClasses(true)
+	sized := a.Child()
+	if got, want := cap(arena.MakeSlice[byte](sized, 0, 100)), cap(arena.MakeSlice[byte](a, 0, 100)); got != want {
+		t.Errorf("Expected child capacity %d like its parent, got %d", want, got)
+	}
+	sized.Delete()
+	a.UseSizeClasses(false)
+
+	// Once the parent is Reset, deleting a stale child must not reclaim its new data
+	b := arena.New(1, arena.BUMP)
+	defer b.Delete()
+	stale := b.Child()
+	arena.Ptr(stale, 1)
+	b.Reset()
+	live := arena.Ptr(b, 42)
+	stale.Delete()
+	if next := arena.Ptr(b, 7); next == live || *live != 42 {
+		t.Errorf("Expected stale child Delete to leave parent data alone, got %d at %p", *live, next)
+	}
+}
+
+func TestArenaScratch(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	// Loop-invariant data allocated before any scratch scope
+	name := a.MakeString("invariant")
+	counts := arena.NewVec[int](a)
+
+	for i := range 5 {
+		var marks [2]*int
+		for k := range marks {
+			a.Scratch(func(tmp *arena.Arena) {
+				marks[k] = arena.Ptr(tmp, i)
+				for j := range 1000 {
+					arena.Ptr(tmp, j) // spill into further chunks
+				}
+				arena.NewStr(tmp).Fields("some temporary fields")
+			})
+		}
+		// The second scope starts where the first did: the offset returned to the mark
+		if marks[0] != marks[1] {
+			t.Errorf("Iteration %d: expected allocation at mark %p, got %p", i, marks[0], marks[1])
+		}
+		counts.Append(i)
+	}
+
+	if name != "invariant" {
+		t.Errorf("Expected invariant string to survive, got %q", name)
+	}
+	for i, v := range counts.Slice() {
+		if v != i {
+			t.Errorf("Expected counts[%d] = %d, got %d", i, i, v)
+		}
+	}
+
+	// The offset returns to the mark after each scope, including on panic
+	var inside *int
+	a.Scratch(func(tmp *arena.Arena) { inside = arena.Ptr(tmp, 1) })
+	if after := arena.Ptr(a, 2); after != inside {
+		t.Errorf("Expected allocation after Scratch at %p, got %p", inside, after)
+	}
+	func() {
+		defer func() { recover() }()
+		a.Scratch(func(tmp *arena.Arena) {
+			inside = arena.Ptr(tmp, 3)
+			panic("boom")
+		})
+	}()
+	if after := arena.Ptr(a, 4); after != inside {
+		t.Errorf("Expected Scratch to rewind on panic to %p, got %p", inside, after)
+	}
+
+	// A Reset inside the scope cancels the rewind, so newer allocations survive
+	b := arena.New(1, arena.BUMP)
+	defer b.Delete()
+	var newer *int
+	b.Scratch(func(tmp *arena.Arena) {
+		arena.Ptr(tmp, 5)
+		b.Reset()
+		newer = arena.Ptr(b, 42)
+	})
+	if next := arena.Ptr(b, 7); next == newer || *newer != 42 {
+		t.Errorf("Expected Scratch not to rewind past a Reset, got %d at %p", *newer, next)
+	}
+}
+
+func TestArenaUsed(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	if used := a.Used(); used != 0 {
+		t.Fatalf("Expected 0 bytes used on a fresh arena, got %d", used)
+	}
+
+	before := a.Used()
+	arena.MakeSlice[int](a, 10, 10)
+	if delta := a.Used() - before; delta < 80 {
+		t.Errorf("Expected MakeSlice[int](10) to use at least 80 bytes, got %d", delta)
+	}
+
+	// Spilling into a new chunk counts the abandoned tail of the filled one
+	before = a.Used()
+	big := arena.MakeSlice[byte](a, syscall.Getpagesize(), syscall.Getpagesize())
+	if delta := a.Used() - before; delta < uint64(len(big)) {
+		t.Errorf("Expected at least %d bytes for a page-sized slice, got %d", len(big), delta)
+	}
+
+	// Children report the parent's usage
+	child := a.Child()
+	before = a.Used()
+	arena.Ptr(child, 1)
+	if child.Used() != a.Used() || a.Used() == before {
+		t.Errorf("Expected child allocation to show in both Used values, got parent %d child %d", a.Used(), child.Used())
+	}
+	child.Delete()
+	if a.Used() != before {
+		t.Errorf("Expected Used to return to %d after child deletion, got %d", before, a.Used())
+	}
+
+	a.Reset()
+	if used := a.Used(); used != 0 {
+		t.Errorf("Expected 0 bytes used after Reset, got %d", used)
+	}
+	if used := arena.New(1, arena.SLAB).Used(); used != 0 {
+		t.Errorf("Expected 0 bytes used for a SLAB arena, got %d", used)
+	}
+}
+
+func TestArenaAllocCount(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	if a.AllocCount() != 0 || a.ResetCount() != 0 {
+		t.Fatalf("Expected zero counts on a fresh arena, got %d allocs and %d resets", a.AllocCount(), a.ResetCount())
+	}
+
+	tests := []struct {
+		name string
+		op   func()
+		want uint64
+	}{
+		{"MakeObject", func() { arena.MakeObject[int](a) }, 1},
+		{"MakeSlice", func() { arena.MakeSlice[int](a, 10, 10) }, 1},
+		{"empty MakeSlice", func() { arena.MakeSlice[int](a, 0, 0) }, 0},
+		{"Ptr x3", func() { arena.Ptr(a, 1); arena.Ptr(a, 2); arena.Ptr(a, 3) }, 3},
+		{"MakeString", func() { a.MakeString("hello") }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := a.AllocCount()
+			tt.op()
+			if n := a.AllocCount() - before; n != tt.want {
+				t.Errorf("Expected %d allocations, got %d", tt.want, n)
+			}
+		})
+	}
+
+	// Allocation count survives Reset; ResetCount counts each Reset
+	allocs := a.AllocCount()
+	for i := range 3 {
+		a.Reset()
+		if n := a.ResetCount(); n != uint64(i+1) {
+			t.Errorf("Expected ResetCount %d, got %d", i+1, n)
+		}
+	}
+	if a.AllocCount() != allocs {
+		t.Errorf("Expected AllocCount %d to survive Reset, got %d", allocs, a.AllocCount())
+	}
+
+	// A child reports its parent's counters; rewinding it is not a Reset
+	child := a.Child()
+	arena.Ptr(child, 1)
+	if child.AllocCount() != allocs+1 || a.AllocCount() != allocs+1 {
+		t.Errorf("Expected child allocation to be counted once, got parent %d child %d", a.AllocCount(), child.AllocCount())
+	}
+	child.Reset()
+	if a.ResetCount() != 3 {
+		t.Errorf("Expected child rewind not to count as Reset, got %d", a.ResetCount())
+	}
+}
+
+func TestArenaString(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	page := syscall.Getpagesize()
+	if s := a.String(); !strings.Contains(s, "type=BUMP") || !strings.Contains(s, "chunks=1") || !strings.Contains(s, "used=0B") {
+		t.Errorf("Fresh arena: got %q", s)
+	}
+
+	// Overflow the first chunk so a second one is added
+	_ = arena.MakeSlice[byte](a, page/2, page/2)
+	_ = arena.MakeSlice[byte](a, page, page)
+
+	s := a.String()
+	expected := fmt.Sprintf("Arena{type=BUMP chunks=2 used=%dKiB reserved=%dKiB}", 2*page/1024, 2*page/1024)
+	if s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+
+	a.Reset()
+	if s := a.String(); !strings.Contains(s, "used=0B") || !strings.Contains(s, "chunks=2") {
+		t.Errorf("After Reset: got %q", s)
+	}
+
+	if s := arena.New(1, arena.SLAB).String(); s != "Arena{type=SLAB}" {
+		t.Errorf("Expected Arena{type=SLAB}, got %q", s)
+	}
+}
+
+func TestArenaStats(t *testing.T) {
+	page := uint64(syscall.Getpagesize())
+
+	t.Run("BUMP", func(t *testing.T) {
+		a := arena.New(1, arena.BUMP)
+		defer a.Delete()
+
+		s := a.Stats()
+		if s != (arena.Stats{BytesReserved: page, ChunkCount: 1}) {
+			t.Errorf("Expected fresh stats with one empty page, got %+v", s)
+		}
+
+		arena.MakeSlice[byte](a, 100, 100)
+		if s := a.Stats(); s.BytesAllocated != a.Used() || s.BytesAllocated < 100 || s.HighWaterMark != s.BytesAllocated {
+			t.Errorf("Expected allocated bytes to match Used and the peak, got %+v (Used %d)", s, a.Used())
+		}
+
+		// Outgrowing the first chunk adds one
+		arena.MakeSlice[byte](a, int(page), int(page))
+		grown := a.Stats()
+		if grown.ChunkCount != 2 || grown.BytesReserved != 2*page {
+			t.Errorf("Expected 2 chunks and %d reserved bytes, got %+v", 2*page, grown)
+		}
+
+		// The high-water mark survives Reset
+		a.Reset()
+		s = a.Stats()
+		if s.BytesAllocated != 0 || s.HighWaterMark != grown.BytesAllocated || s.ChunkCount != 2 {
+			t.Errorf("Expected 0 allocated, peak %d and chunks kept after Reset, got %+v", grown.BytesAllocated, s)
+		}
+		arena.MakeSlice[byte](a, 16, 16)
+		if s := a.Stats(); s.HighWaterMark != grown.BytesAllocated {
+			t.Errorf("Expected peak to stay %d below it, got %d", grown.BytesAllocated, s.HighWaterMark)
+		}
+
+		// Children report the parent's stats, and their rewinds keep the peak
+		child := a.Child()
+		arena.MakeSlice[byte](child, int(2*page), int(2*page))
+		peak := child.Stats().HighWaterMark
+		if child.Stats() != a.Stats() || peak <= grown.BytesAllocated {
+			t.Errorf("Expected child to report the parent's raised peak, got child %+v parent %+v", child.Stats(), a.Stats())
+		}
+		child.Delete()
+		if s := a.Stats(); s.HighWaterMark != peak || s.BytesAllocated >= peak {
+			t.Errorf("Expected peak %d kept after child deletion, got %+v", peak, s)
+		}
+	})
+
+	t.Run("BUDDY", func(t *testing.T) {
+		a := arena.New(2, arena.BUDDY)
+		defer a.Delete()
+
+		if s := a.Stats(); s != (arena.Stats{BytesReserved: 2 * page, ChunkCount: 1}) {
+			t.Errorf("Expected fresh stats with one 2-page region, got %+v", s)
+		}
+		x := arena.MakeSlice[byte](a, 100, 100) // 128-byte block
+		y := arena.MakeSlice[byte](a, 16, 16)
+		if s := a.Stats(); s.BytesAllocated != 144 || s.HighWaterMark != 144 {
+			t.Errorf("Expected 144 allocated bytes, got %+v", s)
+		}
+		arena.DeleteSlice(a, x)
+		arena.DeleteSlice(a, y)
+		if s := a.Stats(); s.BytesAllocated != 0 || s.HighWaterMark != 144 {
+			t.Errorf("Expected 0 allocated with peak 144 after Remove, got %+v", s)
+		}
+		arena.MakeSlice[byte](a, int(4*page), int(4*page)) // needs its own region
+		if s := a.Stats(); s.ChunkCount != 2 || s.BytesReserved != 6*page {
+			t.Errorf("Expected 2 regions and %d reserved bytes, got %+v", 6*page, s)
+		}
+		a.Reset()
+		if s := a.Stats(); s.BytesAllocated != 0 || s.HighWaterMark != 4*page {
+			t.Errorf("Expected peak %d to survive Reset, got %+v", 4*page, s)
+		}
+	})
+
+	t.Run("SLAB", func(t *testing.T) {
+		a := arena.New(1, arena.SLAB)
+		defer a.Delete()
+
+		if s := a.Stats(); s != (arena.Stats{BytesReserved: page, ChunkCount: 1}) {
+			t.Errorf("Expected fresh stats with one run, got %+v", s)
+		}
+		x := arena.Alloc[int64](a) // 256-byte block
+		arena.Alloc[int64](a)
+		if s := a.Stats(); s.BytesAllocated != 512 || s.HighWaterMark != 512 {
+			t.Errorf("Expected 512 allocated bytes, got %+v", s)
+		}
+		arena.DeleteObject(a, x)
+		if s := a.Stats(); s.BytesAllocated != 256 || s.HighWaterMark != 512 {
+			t.Errorf("Expected 256 allocated with peak 512 after Remove, got %+v", s)
+		}
+		arena.MakeSlice[byte](a, int(2*page), int(2*page)) // oversized, its own run
+		if s := a.Stats(); s.ChunkCount != 2 || s.BytesReserved != 3*page || s.HighWaterMark != 256+2*page {
+			t.Errorf("Expected 2 runs and %d reserved bytes, got %+v", 3*page, s)
+		}
+		a.Reset()
+		if s := a.Stats(); s != (arena.Stats{BytesReserved: page, ChunkCount: 1, HighWaterMark: 256 + 2*page}) {
+			t.Errorf("Expected one run and peak %d after Reset, got %+v", 256+2*page, s)
+		}
+	})
+}
+
+func TestArenaMarkRestore(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	kept := arena.Ptr(a, 7)
+	cp := a.Mark()
+	first := arena.Ptr(a, 1)
+	for j := range 2000 {
+		arena.Ptr(a, j) // spill into further chunks
+	}
+	used := a.Used()
+
+	if !a.Restore(cp) {
+		t.Fatalf("Expected Restore to succeed on a bump arena")
+	}
+	if a.Used() >= used {
+		t.Errorf("Expected Restore to reclaim memory, Used %d -> %d", used, a.Used())
+	}
+	if again := arena.Ptr(a, 2); again != first {
+		t.Errorf("Expected allocation after Restore at %p, got %p", first, again)
+	}
+	if *kept != 7 {
+		t.Errorf("Expected allocation before the mark to survive, got %d", *kept)
+	}
+
+	// Nested checkpoints restore innermost first; a checkpoint is reusable
+	outer := a.Mark()
+	arena.Ptr(a, 3)
+	inner := a.Mark()
+	innerPtr := arena.Ptr(a, 4)
+	if !a.Restore(inner) || !a.Restore(inner) {
+		t.Errorf("Expected inner checkpoint to restore repeatedly")
+	}
+	if p := arena.Ptr(a, 5); p != innerPtr {
+		t.Errorf("Expected inner restore to rewind to %p, got %p", innerPtr, p)
+	}
+	if !a.Restore(outer) {
+		t.Errorf("Expected outer checkpoint to restore")
+	}
+	if a.Restore(inner) {
+		t.Errorf("Expected inner checkpoint to be rejected after restoring past it")
+	}
+
+	// Stale and foreign checkpoints are rejected
+	cp = a.Mark()
+	a.Reset()
+	for range 100 {
+		arena.Ptr(a, 0)
+	}
+	if a.Restore(cp) {
+		t.Errorf("Expected checkpoint from before Reset to be rejected")
+	}
+	other := arena.New(1, arena.BUMP)
+	defer other.Delete()
+	if a.Restore(other.Mark()) {
+		t.Errorf("Expected checkpoint from another arena to be rejected")
+	}
+
+	// Children share the parent's checkpoints
+	child := a.Child()
+	cp = child.Mark()
+	inChild := arena.Ptr(child, 1)
+	if !a.Restore(cp) || arena.Ptr(child, 2) != inChild {
+		t.Errorf("Expected parent to restore a checkpoint taken through its child")
+	}
+	child.Delete()
+
+	// Unsupported for allocators that cannot rewind
+	for _, kind := range []arena.Type{arena.SLAB, arena.BUDDY} {
+		b := arena.New(1, kind)
+		if b.Restore(b.Mark()) {
+			t.Errorf("Expected Restore to report false for %s", kind)
+		}
+		b.Delete()
+	}
+}
@@ -0,0 +1,195 @@
+package arena_test
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestMapEncodeDecode(t *testing.T) {
+	src := arena.New(16, arena.BUMP)
+	defer src.Delete()
+
+	m := arena.NewMap[string, int](src)
+	for i := range 500 {
+		m.Set(src.MakeString("key-"+strconv.Itoa(i)), i-250)
+	}
+	m.Set("", 0)
+	m.Set("unicode 世界", 1<<40)
+
+	w := arena.NewWriter(src)
+	if err := m.Encode(w); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	data := arena.CloneSlice(w.Bytes())
+
+	dst := arena.New(16, arena.BUMP)
+	defer dst.Delete()
+
+	restored, err := arena.DecodeMap[string, int](dst, arena.NewReader(dst, data))
+	if err != nil {
+		t.Fatalf("DecodeMap failed: %v", err)
+	}
+	if restored.Len() != m.Len() {
+		t.Fatalf("Expected %d entries, got %d", m.Len(), restored.Len())
+	}
+	for k, v := range m.All() {
+		if got, ok := restored.Get(k); !ok || got != v {
+			t.Errorf("Key %q: expected %d, got %d (found=%v)", k, v, got, ok)
+		}
+	}
+	for k := range restored.Keys() {
+		if k != "" && !arena.OwnsString(dst, k) {
+			t.Errorf("Decoded key %q should live in the destination arena", k)
+			break
+		}
+	}
+
+	// Truncated input reports an error instead of a partial map
+	if _, err := arena.DecodeMap[string, int](dst, arena.NewReader(dst, data[:len(data)/2])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF for truncated input, got %v", err)
+	}
+}
+
+func TestMapEncodeWithCodec(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	type point struct{ X, Y int32 }
+
+	m := arena.NewMap[uint16, point](a)
+	m.Set(1, point{1, 2})
+	m.Set(65535, point{-3, 4})
+
+	w := arena.NewWriter(a)
+	if err := m.Encode(w); !errors.Is(err, arena.ErrUnsupportedType) {
+		t.Fatalf("Expected ErrUnsupportedType for struct values, got %v", err)
+	}
+
+	w.Reset()
+	encode := func(w *arena.Writer, p point) error {
+		arena.EncodeValue(w, p.X)
+		return arena.EncodeValue(w, p.Y)
+	}
+	decode := func(a *arena.Arena, r *arena.Reader) (point, error) {
+		x, err := arena.DecodeValue[int32](a, r)
+		if err != nil {
+			return point{}, err
+		}
+		y, err := arena.DecodeValue[int32](a, r)
+		return point{x, y}, err
+	}
+	if err := m.EncodeWith(w, arena.EncodeValue[uint16], encode); err != nil {
+		t.Fatalf("EncodeWith failed: %v", err)
+	}
+
+	restored, err := arena.DecodeMapWith(a, arena.NewReader(a, w.Bytes()), arena.DecodeValue[uint16], decode)
+	if err != nil {
+		t.Fatalf("DecodeMapWith failed: %v", err)
+	}
+	if p, _ := restored.Get(65535); p != (point{-3, 4}) {
+		t.Errorf("Expected {-3 4}, got %v", p)
+	}
+	if p, _ := restored.Get(1); p != (point{1, 2}) {
+		t.Errorf("Expected {1 2}, got %v", p)
+	}
+}
+
+func TestMapCodecErrorRollback(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[int, int](a)
+	for i := range 20 {
+		m.Set(i, i)
+	}
+	errBad := errors.New("bad value")
+
+	// A codec failing part-way leaves only what was written before EncodeWith
+	w := arena.NewWriter(a)
+	w.WriteString("header")
+	seen := 0
+	failing := func(w *arena.Writer, v int) error {
+		if seen++; seen == 10 {
+			w.WriteString("partial")
+			return errBad
+		}
+		return arena.EncodeValue(w, v)
+	}
+	if err := m.EncodeWith(w, arena.EncodeValue[int], failing); !errors.Is(err, errBad) {
+		t.Fatalf("Expected errBad from EncodeWith, got %v", err)
+	}
+	if got := string(w.Bytes()); got != "header" {
+		t.Errorf("Expected output truncated to %q, got %q", "header", got)
+	}
+
+	w.Reset()
+	if err := m.Encode(w); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	data := arena.CloneSlice(w.Bytes())
+
+	// A decoder failing part-way rewinds the reader; Mark/Restore reclaims the partial map
+	dst := arena.New(4, arena.BUMP)
+	defer dst.Delete()
+	arena.Ptr(dst, 1)
+	used := dst.Used()
+	r := arena.NewReader(dst, data)
+	cp := dst.Mark()
+	decoded := 0
+	decode := func(a *arena.Arena, r *arena.Reader) (int, error) {
+		if decoded++; decoded == 10 {
+			return 0, errBad
+		}
+		return arena.DecodeValue[int](a, r)
+	}
+	if _, err := arena.DecodeMapWith(dst, r, arena.DecodeValue[int], decode); !errors.Is(err, errBad) {
+		t.Fatalf("Expected errBad from DecodeMapWith, got %v", err)
+	}
+	if dst.Used() == used {
+		t.Errorf("Expected the partial map to be left in the arena")
+	}
+	dst.Restore(cp)
+	if dst.Used() != used {
+		t.Errorf("Expected the partial map to be reclaimed, Used %d -> %d", used, dst.Used())
+	}
+	if pos, _ := r.Seek(0, io.SeekCurrent); pos != 0 {
+		t.Errorf("Expected the reader rewound to 0, got %d", pos)
+	}
+	restored, err := arena.DecodeMap[int, int](dst, r)
+	if err != nil || restored.Len() != 20 {
+		t.Errorf("Expected a retry to decode 20 entries, got %v (err %v)", restored, err)
+	}
+}
+
+func TestDecodePastEnd(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	// Seeking beyond the end is allowed; decoding there must fail, not panic
+	r := arena.NewReader(a, []byte{1, 2, 3})
+	if _, err := r.Seek(10, io.SeekStart); err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	if _, err := arena.DecodeValue[int](a, r); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("int: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := arena.DecodeValue[uint32](a, r); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("uint32: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := arena.DecodeValue[string](a, r); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("string: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := arena.DecodeValue[[]byte](a, r); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("[]byte: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := arena.DecodeMap[string, int](a, r); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("DecodeMap: expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Errorf("ReadFrame: expected io.EOF, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package arena_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func readAllCSV(t *testing.T, r *arena.CSVReader) ([][]string, error) {
+	t.Helper()
+	var records [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, slices.Clone(record))
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	tests := []struct {
+		name string
+		data string
+		want [][]string
+	}{
+		{"simple", "a,b,c\n1,2,3\n", [][]string{{"a", "b", "c"}, {"1", "2", "3"}}},
+		{"no trailing newline", "a,b\n1,2", [][]string{{"a", "b"}, {"1", "2"}}},
+		{"empty fields", ",a,,\n", [][]string{{"", "a", "", ""}}},
+		{"quoted comma", `name,"Doe, John",42` + "\n", [][]string{{"name", "Doe, John", "42"}}},
+		{"escaped quotes", `"say ""hi""",x` + "\n", [][]string{{`say "hi"`, "x"}}},
+		{"only escaped quote", `""""` + "\n", [][]string{{`"`}}},
+		{"empty quoted", `"",""` + "\n", [][]string{{"", ""}}},
+		{"embedded newline", "\"line1\nline2\",b\nc,d\n", [][]string{{"line1\nline2", "b"}, {"c", "d"}}},
+		{"crlf", "a,b\r\n\"q,\"\"x\"\"\",c\r\n", [][]string{{"a", "b"}, {`q,"x"`, "c"}}},
+		{"crlf without final newline", "a,b\r\nc,d", [][]string{{"a", "b"}, {"c", "d"}}},
+		{"empty lines skipped", "\na,b\n\r\n\nc\n", [][]string{{"a", "b"}, {"c"}}},
+		{"varying field counts", "a\nb,c,d\n", [][]string{{"a"}, {"b", "c", "d"}}},
+		{"multibyte", "日本,\"東京, 大阪\"\n", [][]string{{"日本", "東京, 大阪"}}},
+		{"empty input", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readAllCSV(t, arena.NewCSVReader(a, tt.data))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+
+			// Agrees with encoding/csv
+			std := csv.NewReader(strings.NewReader(tt.data))
+			std.FieldsPerRecord = -1
+			want, err := std.ReadAll()
+			if err != nil {
+				t.Fatalf("encoding/csv failed: %v", err)
+			}
+			if !slices.EqualFunc(got, want, slices.Equal) {
+				t.Errorf("Expected encoding/csv result %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestCSVReaderErrors(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	tests := []struct {
+		name string
+		data string
+		line string
+	}{
+		{"unterminated quote", "a,\"open\nstill open", "line 1"},
+		{"bare quote", "ok\nab\"c,d\n", "line 2"},
+		{"text after closing quote", "\"a\"b,c\n", "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := arena.NewCSVReader(a, tt.data)
+			_, err := readAllCSV(t, r)
+			if !errors.Is(err, arena.ErrCSVQuote) {
+				t.Fatalf("Expected ErrCSVQuote, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Expected error to mention %s, got %v", tt.line, err)
+			}
+			if _, again := r.Read(); !errors.Is(again, arena.ErrCSVQuote) {
+				t.Errorf("Expected the error to be sticky, got %v", again)
+			}
+		})
+	}
+}
+
+func TestCSVReaderZeroCopy(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	data := strings.Repeat("alpha,\"beta, gamma\",\"d\"\"e\"\n", 100)
+	r := arena.NewCSVReader(a, data)
+	record, _ := r.Read()
+	if unsafe.StringData(record[0]) != unsafe.StringData(data) {
+		t.Errorf("Expected unquoted field to alias the input")
+	}
+	if unsafe.StringData(record[1]) != unsafe.StringData(data[7:]) {
+		t.Errorf("Expected quoted field without escapes to alias the input")
+	}
+	if !arena.OwnsString(a, record[2]) {
+		t.Errorf("Expected unescaped field to be copied into the arena")
+	}
+
+	// The field slice is reused: no heap allocations per record (except for the
+	// allocation profile of arenadebug builds, which is non-nil only there)
+	if n := testing.AllocsPerRun(50, func() { r.Read() }); n != 0 && a.AllocProfile() == nil {
+		t.Errorf("Expected 0 heap allocs per Read, got %v", n)
+	}
+}
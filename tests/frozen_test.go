@@ -0,0 +1,118 @@
+package arena_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestFrozenMap(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	for _, n := range []int{0, 1, 7, 1000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			m := arena.NewMap[string, int](a)
+			for i := range n {
+				m.Set(a.MakeString("key-"+strconv.Itoa(i)), i)
+			}
+			f := m.Freeze()
+
+			if f.Len() != m.Len() {
+				t.Fatalf("Expected Len %d, got %d", m.Len(), f.Len())
+			}
+			m.Range(func(k string, v int) bool {
+				if got, ok := f.Get(k); !ok || got != v {
+					t.Errorf("Get(%s): expected %d, got %d (found=%v)", k, v, got, ok)
+				}
+				return true
+			})
+			seen := 0
+			f.Range(func(k string, v int) bool {
+				if want, ok := m.Get(k); !ok || want != v {
+					t.Errorf("Range yielded %s=%d not in source map", k, v)
+				}
+				seen++
+				return true
+			})
+			if seen != n {
+				t.Errorf("Expected Range to visit %d entries, got %d", n, seen)
+			}
+			if _, ok := f.Get("missing"); ok {
+				t.Errorf("Expected missing key to be absent")
+			}
+
+			// The snapshot is independent of later writes
+			m.Set("key-0", -1)
+			m.Set("added", 1)
+			if _, ok := f.Get("added"); ok {
+				t.Errorf("Expected key added after Freeze to be absent")
+			}
+			if v, ok := f.Get("key-0"); n > 0 && (!ok || v != 0) {
+				t.Errorf("Expected frozen value 0 for key-0, got %d (found=%v)", v, ok)
+			}
+		})
+	}
+}
+
+func TestFrozenMapCustomHashAndConcurrentReads(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	// A poor hash forces long probe sequences
+	m := arena.NewMapHashed[int, int](a, func(k int) uint64 { return uint64(k % 3) })
+	for i := range 200 {
+		m.Set(i, i*i)
+	}
+	f := m.Freeze()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				if v, ok := f.Get(i); !ok || v != i*i {
+					t.Errorf("Get(%d): expected %d, got %d (found=%v)", i, i*i, v, ok)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	n := 0
+	f.Range(func(int, int) bool {
+		n++
+		return n < 5
+	})
+	if n != 5 {
+		t.Errorf("Expected Range to stop after 5 entries, got %d", n)
+	}
+}
+
+func BenchmarkFrozenMapGet(b *testing.B) {
+	a := arena.New(4096, arena.BUMP)
+	defer a.Delete()
+
+	keys := make([]string, 1000)
+	m := arena.NewMap[string, int](a)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+		m.Set(keys[i], i)
+	}
+
+	b.Run("Map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m.Get(keys[i%len(keys)])
+		}
+	})
+	b.Run("FrozenMap", func(b *testing.B) {
+		f := m.Freeze()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			f.Get(keys[i%len(keys)])
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package arena_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestIntMap(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewIntMap[string](a)
+	if _, ok := m.Get(0); ok || m.Len() != 0 {
+		t.Fatal("Expected empty IntMap")
+	}
+
+	m.Set(0, "zero")
+	m.Set(3, "three")
+	m.Set(3, "THREE")   // update does not change Len
+	m.Set(5000, "high") // grows the backing
+	m.Set(64, "")       // a present zero value
+
+	if m.Len() != 4 {
+		t.Errorf("Expected Len 4, got %d", m.Len())
+	}
+	for key, want := range map[int]string{0: "zero", 3: "THREE", 5000: "high", 64: ""} {
+		if v, ok := m.Get(key); !ok || v != want {
+			t.Errorf("Get(%d) = %q, %v; want %q, true", key, v, ok, want)
+		}
+	}
+	for _, key := range []int{1, 2, 63, 65, 4999, 5001, 1 << 20, -1} {
+		if _, ok := m.Get(key); ok || m.Contains(key) {
+			t.Errorf("Expected key %d to be absent", key)
+		}
+	}
+
+	if !m.Delete(3) || m.Delete(3) || m.Delete(7) || m.Delete(-1) {
+		t.Error("Delete should report presence exactly once")
+	}
+	if m.Contains(3) || m.Len() != 3 {
+		t.Errorf("Expected key 3 removed and Len 3, got Len %d", m.Len())
+	}
+
+	var keys []int
+	m.Range(func(k int, v string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if !slices.Equal(keys, []int{0, 64, 5000}) {
+		t.Errorf("Expected ascending keys [0 64 5000], got %v", keys)
+	}
+	keys = keys[:0]
+	for k := range m.All() {
+		keys = append(keys, k)
+		break
+	}
+	if !slices.Equal(keys, []int{0}) {
+		t.Errorf("Expected early stop after key 0, got %v", keys)
+	}
+
+	m.Reset()
+	if m.Len() != 0 || m.Contains(5000) {
+		t.Error("Expected empty IntMap after Reset")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on negative key")
+		}
+	}()
+	m.Set(-1, "negative")
+}
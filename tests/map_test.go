@@ -2,8 +2,14 @@ package arena_test
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unsafe"
 
 	"github.com/thebagchi/arena-go"
 )
@@ -336,6 +342,105 @@ func BenchmarkMap_Range(b *testing.B) {
 	}
 }
 
+// wideKey is a large comparable key, where copying it per entry is measurable
+type wideKey struct {
+	id  int
+	pad [248]byte
+}
+
+func benchmarkWideKeyMap(b *testing.B) (*arena.Arena, *arena.Map[wideKey, int]) {
+	a := arena.New(4096, arena.BUMP)
+	m := arena.NewMap[wideKey, int](a)
+	for i := 0; i < 1000; i++ {
+		m.Set(wideKey{id: i}, i)
+	}
+	b.ResetTimer()
+	return a, m
+}
+
+func BenchmarkMap_RangeWideKey(b *testing.B) {
+	a, m := benchmarkWideKeyMap(b)
+	defer a.Delete()
+
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		m.Range(func(k wideKey, v int) bool {
+			sum += v
+			return true
+		})
+	}
+}
+
+func BenchmarkMap_RangeValuesWideKey(b *testing.B) {
+	a, m := benchmarkWideKeyMap(b)
+	defer a.Delete()
+
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		m.RangeValues(func(v int) bool {
+			sum += v
+			return true
+		})
+	}
+}
+
+func TestMap_RangeValuesKeys(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[int, int](a)
+	for i := range 500 {
+		m.Set(i, i*10)
+	}
+
+	values := make(map[int]bool)
+	m.RangeValues(func(v int) bool {
+		values[v] = true
+		return true
+	})
+	keys := make(map[int]bool)
+	m.RangeKeys(func(k int) bool {
+		keys[k] = true
+		return true
+	})
+	for i := range 500 {
+		if !values[i*10] {
+			t.Errorf("RangeValues: expected to visit value %d", i*10)
+		}
+		if !keys[i] {
+			t.Errorf("RangeKeys: expected to visit key %d", i)
+		}
+	}
+	if len(values) != 500 || len(keys) != 500 {
+		t.Errorf("Expected 500 values and keys, got %d and %d", len(values), len(keys))
+	}
+
+	// Early termination
+	n := 0
+	m.RangeValues(func(int) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Errorf("RangeValues: expected to stop after 3 calls, got %d", n)
+	}
+	n = 0
+	m.RangeKeys(func(int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("RangeKeys: expected to stop after 1 call, got %d", n)
+	}
+
+	// Empty map
+	empty := arena.NewMap[string, int](a)
+	empty.RangeValues(func(int) bool {
+		t.Errorf("RangeValues: expected no calls on an empty map")
+		return true
+	})
+}
+
 func TestMap_GetAllocations(t *testing.T) {
 	a := arena.New(4096, arena.BUMP)
 	defer a.Delete()
@@ -374,3 +479,727 @@ func TestMap_GetAllocations(t *testing.T) {
 		t.Errorf("Clone failed: expected 42, got %d", clone["test"])
 	}
 }
+
+func TestMap_CustomHash(t *testing.T) {
+	a := arena.New(4096, arena.BUMP)
+	defer a.Delete()
+
+	type point struct {
+		name string
+		x    int
+	}
+	calls := 0
+	m := arena.NewMapHashed[point, int](a, func(p point) uint64 {
+		calls++
+		h := uint64(p.x)
+		for i := 0; i < len(p.name); i++ {
+			h = h*31 + uint64(p.name[i])
+		}
+		return h
+	})
+
+	for i := range 100 {
+		m.Set(point{name: "p", x: i}, i)
+	}
+	// Equal keys built from a different string backing must still be found
+	for i := range 100 {
+		name := string([]byte{'p'})
+		if v, ok := m.Get(point{name: name, x: i}); !ok || v != i {
+			t.Errorf("Get(%d): expected %d, got %d (found=%v)", i, i, v, ok)
+		}
+	}
+	if calls == 0 {
+		t.Error("Custom hash function was never called")
+	}
+	if m.Len() != 100 {
+		t.Errorf("Expected length 100, got %d", m.Len())
+	}
+}
+
+func BenchmarkMap_GetCustomHash(b *testing.B) {
+	a := arena.New(4096, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMapHashed[int, int](a, func(k int) uint64 {
+		return uint64(k) * 0x9E3779B97F4A7C15
+	})
+
+	// Pre-populate
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i*2)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i % 1000)
+	}
+}
+
+func BenchmarkMap_GetString(b *testing.B) {
+	a := arena.New(4096, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[string, int](a)
+
+	// Pre-populate
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+		m.Set(keys[i], i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(keys[i%1000])
+	}
+}
+
+func TestMap_HashZeroAllocs(t *testing.T) {
+	a := arena.New(4096, arena.BUMP)
+	defer a.Delete()
+
+	type key struct {
+		name string
+		id   int
+	}
+
+	ints := arena.NewMap[int, int](a)
+	strs := arena.NewMap[string, int](a)
+	structs := arena.NewMap[key, int](a)
+	for i := 0; i < 100; i++ {
+		ints.Set(i, i)
+		strs.Set(strconv.Itoa(i), i)
+		structs.Set(key{name: strconv.Itoa(i), id: i}, i)
+	}
+
+	skey := strconv.Itoa(42)
+	if n := testing.AllocsPerRun(100, func() { ints.Get(42) }); n != 0 {
+		t.Errorf("Get[int]: expected 0 allocs, got %v", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { strs.Get(skey) }); n != 0 {
+		t.Errorf("Get[string]: expected 0 allocs, got %v", n)
+	}
+
+	// Struct keys hash by value, so a key with a distinct string backing still matches
+	k := key{name: string([]byte("42")), id: 42}
+	if v, ok := structs.Get(k); !ok || v != 42 {
+		t.Errorf("Get[struct]: expected 42, got %d (found=%v)", v, ok)
+	}
+	if n := testing.AllocsPerRun(100, func() { structs.Get(k) }); n != 0 {
+		t.Errorf("Get[struct]: expected 0 allocs, got %v", n)
+	}
+}
+
+func TestMap_CloneInto(t *testing.T) {
+	src := arena.New(4, arena.BUMP)
+	dst := arena.New(4, arena.BUMP)
+	defer dst.Delete()
+
+	m := arena.NewMap[string, int](src)
+	for i := range 100 {
+		m.Set(src.MakeString("key-"+strconv.Itoa(i)), i)
+	}
+
+	clone := m.CloneInto(dst)
+	src.Delete()
+
+	if clone.Len() != 100 {
+		t.Fatalf("Expected 100 entries, got %d", clone.Len())
+	}
+	for i := range 100 {
+		if v, ok := clone.Get("key-" + strconv.Itoa(i)); !ok || v != i {
+			t.Errorf("Get(key-%d): expected %d, got %d (found=%v)", i, i, v, ok)
+		}
+	}
+}
+
+func TestMap_CloneDeepInto(t *testing.T) {
+	src := arena.New(4, arena.BUMP)
+	dst := arena.New(4, arena.BUMP)
+	defer dst.Delete()
+
+	m := arena.NewMap[string, *arena.Vec[int]](src)
+	for i := range 20 {
+		m.Set(src.MakeString("key-"+strconv.Itoa(i)), arena.Ptr(src, *arena.NewVec(src, i, i*2, i*3)))
+	}
+
+	clone := arena.CloneMapDeepInto(m, dst, func(v *arena.Vec[int], a *arena.Arena) *arena.Vec[int] {
+		return arena.Ptr(a, *arena.NewVec(a, v.Slice()...))
+	})
+	src.Delete()
+
+	if clone.Len() != 20 {
+		t.Fatalf("Expected 20 entries, got %d", clone.Len())
+	}
+	for i := range 20 {
+		v, ok := clone.Get("key-" + strconv.Itoa(i))
+		if !ok {
+			t.Fatalf("Expected key-%d to be present", i)
+		}
+		if !dst.Owns(unsafe.Pointer(v)) {
+			t.Errorf("key-%d: expected Vec to be allocated in dst", i)
+		}
+		if got, want := v.Slice(), []int{i, i * 2, i * 3}; !slices.Equal(got, want) {
+			t.Errorf("key-%d: expected %v, got %v", i, want, got)
+		}
+	}
+
+	// The copies are independent: growing one does not affect another.
+	v, _ := clone.Get("key-1")
+	v.Append(99)
+	if w, _ := clone.Get("key-2"); w.Len() != 3 {
+		t.Errorf("Expected key-2 to keep 3 elements, got %d", w.Len())
+	}
+}
+
+func TestMap_GetWithHash(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	for _, tt := range []struct {
+		name string
+		m    *arena.Map[string, int]
+	}{
+		{"maphash", arena.NewMap[string, int](a)},
+		{"custom hasher", arena.NewMapHashed[string, int](a, func(k string) uint64 { return uint64(len(k)) * 31 })},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			key := strings.Repeat("segment/", 64)
+
+			v, ok, h := m.GetWithHash(key)
+			if ok || v != 0 {
+				t.Fatalf("Expected missing key, got %d (found=%v)", v, ok)
+			}
+			m.SetWithHash(key, v+1, h)
+
+			// Round-trip through the normal API: the stored entry is reachable
+			if got, ok := m.Get(key); !ok || got != 1 {
+				t.Fatalf("Expected 1 after SetWithHash, got %d (found=%v)", got, ok)
+			}
+
+			// Hash is stable and read-modify-write updates in place
+			for i := 2; i <= 100; i++ {
+				v, ok, h2 := m.GetWithHash(key)
+				if !ok || h2 != h {
+					t.Fatalf("Expected stable hash and present key, got hash %d vs %d (found=%v)", h2, h, ok)
+				}
+				m.SetWithHash(key, v+1, h2)
+			}
+			if got, _ := m.Get(key); got != 100 || m.Len() != 1 {
+				t.Errorf("Expected single entry with value 100, got %d with Len %d", got, m.Len())
+			}
+
+			// Survives growth, which rehashes with the stored hash
+			for i := range 200 {
+				m.Set(a.MakeString("k"+strconv.Itoa(i)), i)
+			}
+			m.Delete(key)
+			if _, ok := m.Get(key); ok {
+				t.Errorf("Expected key set via SetWithHash to be deletable")
+			}
+		})
+	}
+}
+
+func TestMap_GetOr(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[string, int](a)
+	m.Set("timeout", 30)
+	m.Set("zero", 0)
+
+	if v := m.GetOr("timeout", 10); v != 30 {
+		t.Errorf("Expected 30 for present key, got %d", v)
+	}
+	if v := m.GetOr("zero", 10); v != 0 {
+		t.Errorf("Expected stored 0 rather than default, got %d", v)
+	}
+	if v := m.GetOr("retries", 3); v != 3 {
+		t.Errorf("Expected default 3 for absent key, got %d", v)
+	}
+	if v := m.GetOrZero("timeout"); v != 30 {
+		t.Errorf("Expected 30 for present key, got %d", v)
+	}
+	if v := m.GetOrZero("retries"); v != 0 {
+		t.Errorf("Expected 0 for absent key, got %d", v)
+	}
+}
+
+func TestMap_RangeOrdered(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[string, int](a)
+	for i, k := range []string{"pear", "apple", "fig", "banana", "cherry", "date"} {
+		m.Set(k, i)
+	}
+
+	var keys []string
+	arena.MapRangeOrdered(m, func(k string, v int) bool {
+		if got, _ := m.Get(k); got != v {
+			t.Errorf("Expected value %d for %q, got %d", got, k, v)
+		}
+		keys = append(keys, k)
+		return true
+	})
+	expected := []string{"apple", "banana", "cherry", "date", "fig", "pear"}
+	if !slices.Equal(keys, expected) {
+		t.Errorf("Expected %v, got %v", expected, keys)
+	}
+
+	// Early termination
+	keys = keys[:0]
+	arena.MapRangeOrdered(m, func(k string, v int) bool {
+		keys = append(keys, k)
+		return len(keys) < 3
+	})
+	if !slices.Equal(keys, expected[:3]) {
+		t.Errorf("Expected early stop after %v, got %v", expected[:3], keys)
+	}
+
+	// Repeated calls must not consume arena memory
+	used := a.Used()
+	for range 100 {
+		arena.MapRangeOrdered(m, func(k string, v int) bool { return true })
+	}
+	if a.Used() != used {
+		t.Errorf("Expected no arena growth from MapRangeOrdered, Used %d -> %d", used, a.Used())
+	}
+
+	arena.MapRangeOrdered(arena.NewMap[int, int](a), func(k, v int) bool {
+		t.Error("Expected no calls for an empty map")
+		return true
+	})
+}
+
+func TestMap_AutoShrink(t *testing.T) {
+	a := arena.New(256, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[int, int](a)
+	m.UseAutoShrink(true)
+	for i := 0; i < 10000; i++ {
+		m.Set(i, i*2)
+	}
+	grown := m.Capacity()
+	if grown < 10000 {
+		t.Fatalf("Expected at least 10000 buckets after filling, got %d", grown)
+	}
+
+	for i := 0; i < 10000; i++ {
+		if i%1000 != 0 {
+			m.Delete(i)
+		}
+	}
+	if m.Len() != 10 {
+		t.Fatalf("Expected 10 survivors, got %d", m.Len())
+	}
+	if c := m.Capacity(); c >= grown || c > 128 {
+		t.Errorf("Expected capacity to shrink from %d to at most 128, got %d", grown, c)
+	}
+	for i := 0; i < 10000; i += 1000 {
+		if v, ok := m.Get(i); !ok || v != i*2 {
+			t.Errorf("Survivor %d: expected %d, got %d, %v", i, i*2, v, ok)
+		}
+	}
+
+	// Never shrinks below the initial bucket count
+	for i := 0; i < 10000; i += 1000 {
+		m.Delete(i)
+	}
+	if c := m.Capacity(); c != arena.INITIAL_BUCKET_COUNT {
+		t.Errorf("Expected %d buckets when empty, got %d", arena.INITIAL_BUCKET_COUNT, c)
+	}
+
+	// Deleting during iteration does not shrink under an open iterator
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+	grown = m.Capacity()
+	seen := map[int]int{}
+	it := m.Iter()
+	for k, _, ok := it.Next(); ok; k, _, ok = it.Next() {
+		seen[k]++
+		m.Delete(k)
+	}
+	if len(seen) != 1000 {
+		t.Errorf("Expected all 1000 keys from the iterator, got %d", len(seen))
+	}
+	for k, n := range seen {
+		if n != 1 {
+			t.Errorf("Expected key %d once, got %d times", k, n)
+		}
+	}
+	m.Set(1, 1)
+	m.Set(2, 2)
+	m.Delete(2)
+	if c := m.Capacity(); c >= grown {
+		t.Errorf("Expected the shrink to happen after iteration, capacity still %d", c)
+	}
+
+	// Without AutoShrink, capacity is kept
+	plain := arena.NewMap[int, int](a)
+	for i := 0; i < 1000; i++ {
+		plain.Set(i, i)
+	}
+	c := plain.Capacity()
+	for i := 0; i < 1000; i++ {
+		plain.Delete(i)
+	}
+	if plain.Capacity() != c {
+		t.Errorf("Expected capacity %d to be kept without AutoShrink, got %d", c, plain.Capacity())
+	}
+}
+
+// countingAllocator counts Alloc calls made through it
+type countingAllocator struct {
+	arena.Allocator
+	allocs int
+}
+
+func (c *countingAllocator) Alloc(size, align uint64) unsafe.Pointer {
+	c.allocs++
+	return c.Allocator.Alloc(size, align)
+}
+
+func TestMap_EntryFreeList(t *testing.T) {
+	counter := &countingAllocator{Allocator: arena.NewBumpAllocator(64 * 1024)}
+	a := &arena.Arena{Allocator: counter}
+	defer a.Delete()
+
+	m := arena.NewMap[int, string](a)
+	for i := 0; i < 100; i++ {
+		m.Set(i, "warm")
+	}
+	for i := 0; i < 100; i++ {
+		m.Delete(i)
+	}
+
+	warm := counter.allocs
+	for cycle := 0; cycle < 50; cycle++ {
+		for i := 0; i < 100; i++ {
+			m.Set(cycle*100+i, "churn")
+		}
+		for i := 0; i < 100; i++ {
+			m.Delete(cycle*100 + i)
+		}
+	}
+	if counter.allocs != warm {
+		t.Errorf("Expected no arena allocations once the free list is warm, got %d more", counter.allocs-warm)
+	}
+
+	// Recycled entries hold the new data, and Reset recycles too
+	for i := 0; i < 100; i++ {
+		m.Set(i, strconv.Itoa(i))
+	}
+	for i := 0; i < 100; i++ {
+		if v, ok := m.Get(i); !ok || v != strconv.Itoa(i) {
+			t.Fatalf("Get(%d) = %q, %v", i, v, ok)
+		}
+	}
+	m.Reset()
+	for i := 0; i < 100; i++ {
+		m.Set(i, "again")
+	}
+	if counter.allocs != warm || m.Len() != 100 {
+		t.Errorf("Expected Reset entries to be reused (allocs +%d, len %d)", counter.allocs-warm, m.Len())
+	}
+}
+
+func TestMap_ComputeOnce(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[int, int](a)
+	var calls [10]atomic.Int32
+
+	var wg sync.WaitGroup
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := (g + i) % len(calls)
+				v := m.ComputeOnce(key, func() int {
+					calls[key].Add(1)
+					time.Sleep(time.Millisecond) // widen the window for concurrent misses
+					return key * 10
+				})
+				if v != key*10 {
+					t.Errorf("ComputeOnce(%d) = %d, want %d", key, v, key*10)
+				}
+				m.Get(key)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for key := range calls {
+		if n := calls[key].Load(); n != 1 {
+			t.Errorf("Expected fn to run once for key %d, ran %d times", key, n)
+		}
+	}
+	if m.Len() != len(calls) {
+		t.Errorf("Expected %d entries, got %d", len(calls), m.Len())
+	}
+}
+
+func TestMap_ComputeOncePanic(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[string, int](a)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the panic from fn to propagate")
+			}
+		}()
+		m.ComputeOnce("key", func() int { panic("boom") })
+	}()
+	if _, ok := m.Get("key"); ok {
+		t.Error("Expected nothing stored after fn panicked")
+	}
+	if v := m.ComputeOnce("key", func() int { return 7 }); v != 7 {
+		t.Errorf("Expected retry to compute 7, got %d", v)
+	}
+}
+
+func TestMap_IterSurvivesDelete(t *testing.T) {
+	counter := &countingAllocator{Allocator: arena.NewBumpAllocator(64 * 1024)}
+	a := &arena.Arena{Allocator: counter}
+	defer a.Delete()
+
+	m := arena.NewMap[int, int](a)
+	for i := 1; i <= 50; i++ {
+		m.Set(i, i*10)
+	}
+	it := m.Iter()
+	if _, _, ok := it.Next(); !ok {
+		t.Fatal("Expected a first pair")
+	}
+
+	// Delete every key, including the one the iterator stands on, then refill
+	for i := 1; i <= 50; i++ {
+		m.Delete(i)
+	}
+	before := counter.allocs
+	for i := 101; i <= 150; i++ {
+		m.Set(i, i*10)
+	}
+	if counter.allocs == before {
+		t.Errorf("Expected no entry recycling while an iterator is open")
+	}
+	// The iterator may see deleted or new pairs, but never recycled free-list nodes
+	for k, v, ok := it.Next(); ok; k, v, ok = it.Next() {
+		if k < 1 || k > 150 || v != k*10 {
+			t.Fatalf("Expected only real pairs from the open iterator, got %d=%d", k, v)
+		}
+	}
+
+	// Once every iterator has finished, deleted entries are recycled again
+	for i := 101; i <= 150; i++ {
+		m.Delete(i)
+	}
+	before = counter.allocs
+	for i := 201; i <= 250; i++ {
+		m.Set(i, i*10)
+	}
+	if counter.allocs != before {
+		t.Errorf("Expected recycled entries after the iterator finished, got %d allocations", counter.allocs-before)
+	}
+
+	// Close releases an abandoned iterator
+	it = m.Iter()
+	it.Next()
+	it.Close()
+	it.Close()
+	for i := 201; i <= 250; i++ {
+		m.Delete(i)
+	}
+	before = counter.allocs
+	for i := 301; i <= 350; i++ {
+		m.Set(i, i*10)
+	}
+	if counter.allocs != before {
+		t.Errorf("Expected recycled entries after Close, got %d allocations", counter.allocs-before)
+	}
+	if _, _, ok := it.Next(); ok {
+		t.Errorf("Expected a closed iterator to be exhausted")
+	}
+}
+
+func TestMap_GetRef(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[string, [256]byte](a)
+	m.Set("block", [256]byte{1})
+
+	ref, ok := m.GetRef("block")
+	if !ok || ref[0] != 1 {
+		t.Fatalf("GetRef(block) = %v, %v", ref, ok)
+	}
+	ref[255] = 42
+
+	if v, _ := m.Get("block"); v[0] != 1 || v[255] != 42 {
+		t.Errorf("Get after GetRef mutation: expected [0]=1 [255]=42, got %d %d", v[0], v[255])
+	}
+	if ref, ok := m.GetRef("missing"); ok || ref != nil {
+		t.Error("GetRef(missing) should return nil, false")
+	}
+}
+
+func TestMap_SetOperations(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	build := func(keys ...string) *arena.Map[string, int] {
+		m := arena.NewMap[string, int](a)
+		for i, k := range keys {
+			m.Set(k, i)
+		}
+		return m
+	}
+	sorted := func(keys []string) []string {
+		s := slices.Clone(keys)
+		slices.Sort(s)
+		return s
+	}
+
+	tests := []struct {
+		name      string
+		m, other  []string
+		diff      []string
+		intersect []string
+		union     []string
+	}{
+		{"overlapping", []string{"a", "b", "c"}, []string{"b", "c", "d"}, []string{"a"}, []string{"b", "c"}, []string{"a", "b", "c", "d"}},
+		{"disjoint", []string{"a", "b"}, []string{"x", "y"}, []string{"a", "b"}, []string{}, []string{"a", "b", "x", "y"}},
+		{"identical", []string{"a", "b", "c"}, []string{"c", "b", "a"}, []string{}, []string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{"subset", []string{"a"}, []string{"a", "b", "c"}, []string{}, []string{"a"}, []string{"a", "b", "c"}},
+		{"empty other", []string{"a", "b"}, nil, []string{"a", "b"}, []string{}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, other := build(tt.m...), build(tt.other...)
+			if got := sorted(m.DiffKeys(other)); !slices.Equal(got, tt.diff) {
+				t.Errorf("Expected DiffKeys %v, got %v", tt.diff, got)
+			}
+			if got := sorted(m.IntersectKeys(other)); !slices.Equal(got, tt.intersect) {
+				t.Errorf("Expected IntersectKeys %v, got %v", tt.intersect, got)
+			}
+			if got := sorted(other.IntersectKeys(m)); !slices.Equal(got, tt.intersect) {
+				t.Errorf("Expected IntersectKeys to be symmetric, got %v", got)
+			}
+			union := m.UnionKeys(other)
+			if got := sorted(union); !slices.Equal(got, tt.union) {
+				t.Errorf("Expected UnionKeys %v, got %v", tt.union, got)
+			}
+			if len(union) > 0 && !arena.OwnsSlice(a, union) {
+				t.Errorf("Expected result to be allocated in the arena")
+			}
+		})
+	}
+
+	t.Run("self", func(t *testing.T) {
+		m := build("a", "b")
+		if got := m.DiffKeys(m); len(got) != 0 {
+			t.Errorf("Expected empty DiffKeys with itself, got %v", got)
+		}
+		if got := sorted(m.IntersectKeys(m)); !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("Expected IntersectKeys with itself to be all keys, got %v", got)
+		}
+		if got := sorted(m.UnionKeys(m)); !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("Expected UnionKeys with itself to be all keys, got %v", got)
+		}
+	})
+
+	t.Run("concurrent opposite order", func(t *testing.T) {
+		m, other := build("a", "b", "c"), build("b", "c", "d")
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 200 {
+					if i%2 == 0 {
+						m.DiffKeys(other)
+					} else {
+						other.DiffKeys(m)
+					}
+					other.Set("d", i)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestMap_PutFiltered(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	src := arena.NewMap[string, int](a)
+	for i := range 100 {
+		src.Set(a.MakeString("k"+strconv.Itoa(i)), i)
+	}
+
+	even := arena.NewMap[string, int](a)
+	even.Set("k1", -1)    // odd key already present: kept as is
+	even.Set("k2", -2)    // even key already present: overwritten
+	even.Set("extra", 42) // unrelated key: kept
+	even.PutFiltered(src, func(_ string, v int) bool { return v%2 == 0 })
+
+	if even.Len() != 50+2 {
+		t.Errorf("Expected 52 entries, got %d", even.Len())
+	}
+	for i := range 100 {
+		got, ok := even.Get("k" + strconv.Itoa(i))
+		switch {
+		case i%2 == 0 && (!ok || got != i):
+			t.Errorf("Expected k%d=%d to be copied, got %d (ok=%v)", i, i, got, ok)
+		case i == 1 && got != -1:
+			t.Errorf("Expected existing k1 to be kept, got %d", got)
+		case i%2 == 1 && i != 1 && ok:
+			t.Errorf("Expected odd k%d to be filtered out", i)
+		}
+	}
+	if v, _ := even.Get("extra"); v != 42 {
+		t.Errorf("Expected unrelated entry to be kept, got %d", v)
+	}
+	if src.Len() != 100 {
+		t.Errorf("Expected source to be unchanged, got %d entries", src.Len())
+	}
+
+	// Copying into itself is a no-op rather than a deadlock
+	src.PutFiltered(src, func(string, int) bool { return true })
+	if src.Len() != 100 {
+		t.Errorf("Expected self copy to leave 100 entries, got %d", src.Len())
+	}
+
+	// Opposite-direction copies run concurrently without deadlock
+	x, y := arena.NewMap[string, int](a), arena.NewMap[string, int](a)
+	x.Set("x", 1)
+	y.Set("y", 2)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				if i%2 == 0 {
+					x.PutFiltered(y, func(string, int) bool { return true })
+				} else {
+					y.PutFiltered(x, func(string, int) bool { return true })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if x.Len() != 2 || y.Len() != 2 {
+		t.Errorf("Expected both maps to hold 2 entries, got %d and %d", x.Len(), y.Len())
+	}
+}
@@ -0,0 +1,107 @@
+package arena_test
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestMultiMapAddGet(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	mm := arena.NewMultiMap[string, int](a)
+	for i := range 40 {
+		mm.Add("alice", i)
+	}
+	mm.Add("bob", 100)
+	mm.Add("bob", 200)
+
+	alice := mm.Get("alice")
+	if len(alice) != 40 || mm.Count("alice") != 40 {
+		t.Fatalf("Expected 40 values for alice, got %d", len(alice))
+	}
+	for i, v := range alice {
+		if v != i {
+			t.Errorf("alice[%d]: expected %d, got %d", i, i, v)
+		}
+	}
+	if !reflect.DeepEqual(mm.Get("bob"), []int{100, 200}) {
+		t.Errorf("Expected bob = [100 200], got %v", mm.Get("bob"))
+	}
+	if mm.Get("carol") != nil || mm.Count("carol") != 0 || mm.Contains("carol") {
+		t.Error("Missing key should have no values")
+	}
+	if mm.Len() != 2 || mm.Size() != 42 {
+		t.Errorf("Expected 2 keys / 42 values, got %d / %d", mm.Len(), mm.Size())
+	}
+
+	var keys []string
+	for k := range mm.Keys() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"alice", "bob"}) {
+		t.Errorf("Keys: expected [alice bob], got %v", keys)
+	}
+}
+
+func TestMultiMapDelete(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	mm := arena.NewMultiMap[int, string](a)
+	mm.Add(1, "a")
+	mm.Add(1, "b")
+	mm.Add(2, "c")
+
+	if n := mm.Delete(1); n != 2 {
+		t.Errorf("Delete(1): expected 2 values removed, got %d", n)
+	}
+	if mm.Contains(1) || mm.Len() != 1 || mm.Size() != 1 {
+		t.Errorf("After delete: Contains(1)=%v Len=%d Size=%d", mm.Contains(1), mm.Len(), mm.Size())
+	}
+	if n := mm.Delete(1); n != 0 {
+		t.Errorf("Deleting a missing key should remove 0 values, got %d", n)
+	}
+
+	// A deleted key starts over with a fresh list
+	mm.Add(1, "z")
+	if !reflect.DeepEqual(mm.Get(1), []string{"z"}) {
+		t.Errorf("Expected fresh list [z], got %v", mm.Get(1))
+	}
+}
+
+func TestMultiMapSurvivesGC(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	// The value lists must stay reachable across collections between calls. Filling
+	// the heap with same-sized junk overwrites any list header the GC freed.
+	mm := arena.NewMultiMap[int, int](a)
+	var (
+		x    int
+		junk []*[4]*int
+	)
+	for round := range 3 {
+		for k := range 50 {
+			mm.Add(k, round)
+		}
+		runtime.GC()
+		for range 1000 {
+			junk = append(junk, &[4]*int{&x, &x, &x, &x})
+		}
+	}
+	runtime.KeepAlive(junk)
+	for k := range 50 {
+		if got := mm.Get(k); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+			t.Fatalf("Expected key %d = [0 1 2] after GC, got %v", k, got)
+		}
+	}
+	if mm.Size() != 150 {
+		t.Errorf("Expected 150 values, got %d", mm.Size())
+	}
+}
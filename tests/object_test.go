@@ -1,6 +1,7 @@
 package arena_test
 
 import (
+	"sync/atomic"
 	"testing"
 	"unsafe"
 
@@ -165,3 +166,190 @@ func TestPtr(t *testing.T) {
 		t.Errorf("Expected slice [1 2 3], got %v", *slicePtr)
 	}
 }
+
+func TestMakeStringUnsafe(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	source := a.MakeString("key=value")
+
+	// Substring of arena memory is returned as a view
+	view := a.MakeStringUnsafe(source[4:])
+	if view != "value" {
+		t.Errorf("Expected view 'value', got %q", view)
+	}
+	if unsafe.StringData(view) != unsafe.StringData(source[4:]) {
+		t.Error("MakeStringUnsafe should not copy arena-owned strings")
+	}
+
+	// Heap strings are copied into the arena
+	heap := string([]byte("heap"))
+	copied := a.MakeStringUnsafe(heap)
+	if copied != heap {
+		t.Errorf("Expected copy 'heap', got %q", copied)
+	}
+	if !arena.OwnsString(a, copied) {
+		t.Error("MakeStringUnsafe should copy non-arena strings into the arena")
+	}
+
+	// Small and large strings round-trip through MakeString
+	for _, s := range []string{"a", "sixteen-bytes-ok", "a string longer than the small string size"} {
+		if got := a.MakeString(s); got != s {
+			t.Errorf("MakeString(%q) = %q", s, got)
+		}
+	}
+}
+
+func TestMakeSliceSizeClasses(t *testing.T) {
+	distinct := func(a *arena.Arena) int {
+		caps := make(map[int]bool)
+		for n := 1; n <= 1000; n++ {
+			s := arena.MakeSlice[byte](a, n, n)
+			if len(s) != n || cap(s) < n {
+				t.Fatalf("MakeSlice(%d): len %d cap %d", n, len(s), cap(s))
+			}
+			if cap(s) > n+n/4+16 {
+				t.Fatalf("MakeSlice(%d): cap %d wastes more than 25%%", n, cap(s))
+			}
+			caps[cap(s)] = true
+		}
+		return len(caps)
+	}
+
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	exact := distinct(a)
+	a.UseSizeClasses(true)
+	classed := distinct(a)
+
+	if exact != 1000 {
+		t.Errorf("Expected 1000 distinct capacities without size classes, got %d", exact)
+	}
+	if classed > 30 {
+		t.Errorf("Expected few distinct capacities with size classes, got %d", classed)
+	}
+}
+
+func TestMakeSliceNCap(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	n := arena.MakeSliceN[int](a, 10)
+	if len(n) != 10 || cap(n) != 10 {
+		t.Errorf("MakeSliceN(10): expected len 10 cap 10, got len %d cap %d", len(n), cap(n))
+	}
+
+	c := arena.MakeSliceCap[int](a, 10)
+	if len(c) != 0 || cap(c) != 10 {
+		t.Errorf("MakeSliceCap(10): expected len 0 cap 10, got len %d cap %d", len(c), cap(c))
+	}
+
+	if s := arena.MakeSliceCap[int](a, 0); s != nil {
+		t.Errorf("MakeSliceCap(0): expected nil, got %v", s)
+	}
+	if s := arena.MakeSliceN[int](a, 0); s != nil {
+		t.Errorf("MakeSliceN(0): expected nil, got %v", s)
+	}
+}
+
+func TestAllocInit(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	type session struct {
+		ID      int
+		Scratch [512]byte
+		Events  *arena.Vec[string]
+	}
+
+	// Dirty the memory so AllocInit has to zero it after Reset
+	dirty := arena.MakeSliceN[byte](a, 1024)
+	for i := range dirty {
+		dirty[i] = 0xFF
+	}
+	a.Reset()
+
+	s := arena.AllocInit(a, func(s *session) {
+		if s.ID != 0 || s.Scratch[100] != 0 || s.Events != nil {
+			t.Error("Expected zeroed object before init")
+		}
+		s.ID = 7
+		s.Events = arena.NewVec[string](a)
+	})
+	s.Events.Append("login", "logout")
+
+	if s.ID != 7 || s.Events.Len() != 2 {
+		t.Errorf("Expected ID 7 with 2 events, got %d with %d", s.ID, s.Events.Len())
+	}
+	if !a.Owns(unsafe.Pointer(s)) || !a.Owns(unsafe.Pointer(&s.Events.Slice()[0])) {
+		t.Error("Expected the object and its Vec data to live in the arena")
+	}
+	if p := arena.AllocInit[int](a, nil); *p != 0 {
+		t.Errorf("Expected zero int with nil init, got %d", *p)
+	}
+}
+
+// counterBlock mixes an 8-byte-aligned atomic with a 32-byte payload
+type counterBlock struct {
+	hits atomic.Int64
+	tag  byte
+	data [32]byte
+}
+
+func checkAlignment[T any](t *testing.T, a *arena.Arena) {
+	t.Helper()
+	var zero T
+	align := max(unsafe.Alignof(zero), arena.MIN_ALIGN)
+
+	check := func(name string, p unsafe.Pointer) {
+		t.Helper()
+		if uintptr(p)%align != 0 {
+			t.Errorf("%s[%T]: expected %d-byte alignment, got address %#x", name, zero, align, p)
+		}
+	}
+	misalign := func() { arena.MakeSlice[byte](a, 1, 1) } // leave the bump offset misaligned
+
+	misalign()
+	s := arena.MakeSlice[T](a, 4, 4)
+	check("MakeSlice", unsafe.Pointer(&s[0]))
+	for i := range s {
+		if p := uintptr(unsafe.Pointer(&s[i])); p%unsafe.Alignof(zero) != 0 {
+			t.Errorf("MakeSlice[%T]: element %d misaligned at %#x", zero, i, p)
+		}
+	}
+	misalign()
+	check("Alloc", unsafe.Pointer(arena.Alloc[T](a)))
+	misalign()
+	check("MakeObject", unsafe.Pointer(arena.MakeObject[T](a)))
+	misalign()
+	check("AllocInit", unsafe.Pointer(arena.AllocInit[T](a, nil)))
+	misalign()
+	check("Ptr", unsafe.Pointer(arena.Ptr(a, zero)))
+
+	// Values stored in container nodes keep their natural alignment
+	misalign()
+	m := arena.NewMap[int, T](a)
+	m.Set(1, zero)
+	if p, _ := m.GetRef(1); uintptr(unsafe.Pointer(p))%unsafe.Alignof(zero) != 0 {
+		t.Errorf("Map[%T]: value misaligned at %p", zero, p)
+	}
+	misalign()
+	sl := arena.NewSkipList[int, T](a)
+	sl.Insert(1, zero)
+	if p, _ := sl.SearchRef(1); uintptr(unsafe.Pointer(p))%unsafe.Alignof(zero) != 0 {
+		t.Errorf("SkipList[%T]: value misaligned at %p", zero, p)
+	}
+}
+
+func TestAllocAlignment(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	checkAlignment[byte](t, a)
+	checkAlignment[int64](t, a)
+	checkAlignment[complex128](t, a)
+	checkAlignment[counterBlock](t, a)
+	checkAlignment[[3]uint16](t, a)
+	checkAlignment[struct{}](t, a)
+}
@@ -0,0 +1,84 @@
+package arena_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestOptional(t *testing.T) {
+	tests := []struct {
+		name   string
+		opt    arena.Optional[int]
+		some   bool
+		orElse int
+	}{
+		{"some", arena.Some(42), true, 42},
+		{"some zero", arena.Some(0), true, 0},
+		{"none", arena.None[int](), false, -1},
+		{"of found", arena.OptionalOf(7, true), true, 7},
+		{"of missing", arena.OptionalOf(7, false), false, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opt.IsSome() != tt.some {
+				t.Errorf("Expected IsSome=%v, got %v", tt.some, tt.opt.IsSome())
+			}
+			if v, ok := tt.opt.Get(); ok != tt.some || (ok && v != tt.orElse) {
+				t.Errorf("Expected Get=(%d, %v), got (%d, %v)", tt.orElse, tt.some, v, ok)
+			}
+			if v := tt.opt.OrElse(-1); v != tt.orElse {
+				t.Errorf("Expected OrElse(-1)=%d, got %d", tt.orElse, v)
+			}
+		})
+	}
+}
+
+func TestOptionalChaining(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	m := arena.NewMap[string, string](a)
+	m.Set("port", "9090")
+	m.Set("bad", "nope")
+
+	parse := func(s string) arena.Optional[int] {
+		n, err := strconv.Atoi(s)
+		return arena.OptionalOf(n, err == nil)
+	}
+	port := func(key string) int {
+		o := arena.FlatMapOptional(arena.OptionalOf(m.Get(key)), parse)
+		return arena.MapOptional(o, func(n int) int { return n + 1 }).OrElse(8080)
+	}
+
+	if p := port("port"); p != 9091 {
+		t.Errorf("Expected 9091 for a present, valid value, got %d", p)
+	}
+	if p := port("bad"); p != 8080 {
+		t.Errorf("Expected default for an unparsable value, got %d", p)
+	}
+	if p := port("missing"); p != 8080 {
+		t.Errorf("Expected default for a missing key, got %d", p)
+	}
+
+	// Map is not called on None
+	called := false
+	arena.MapOptional(arena.None[int](), func(n int) string { called = true; return "" })
+	if called {
+		t.Errorf("Expected MapOptional to skip fn for None")
+	}
+
+	// Filter keeps only matching values
+	v := arena.NewVec(a, 3, 8, 5)
+	even := func(n int) bool { return n%2 == 0 }
+	if _, ok := arena.OptionalOf(v.Get(0)).Filter(even).Get(); ok {
+		t.Errorf("Expected odd value to be filtered out")
+	}
+	if n := arena.OptionalOf(v.Get(1)).Filter(even).OrElse(0); n != 8 {
+		t.Errorf("Expected even value 8 to pass the filter, got %d", n)
+	}
+	if _, ok := arena.OptionalOf(v.Get(10)).Get(); ok {
+		t.Errorf("Expected out-of-range Get to yield None")
+	}
+}
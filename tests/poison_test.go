@@ -0,0 +1,54 @@
+//go:build arenadebug
+
+package arena_test
+
+import (
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestPoisonOnReset(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	stale := arena.MakeSliceN[byte](a, 256)
+	for i := range stale {
+		stale[i] = byte(i)
+	}
+
+	a.Reset()
+	for i, c := range stale {
+		if c != arena.POISON_BYTE {
+			t.Fatalf("Expected poison 0x%X at %d after Reset, got 0x%X", arena.POISON_BYTE, i, c)
+		}
+	}
+
+	// Reused memory is still handed out zeroed
+	fresh := arena.MakeSliceN[byte](a, 256)
+	for i, c := range fresh {
+		if c != 0 {
+			t.Fatalf("Expected zeroed memory at %d after reuse, got 0x%X", i, c)
+		}
+	}
+}
+
+func TestPoisonChildArena(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	kept := arena.MakeSliceN[byte](a, 64)
+	kept[0] = 1
+
+	child := a.Child()
+	stale := arena.MakeSliceN[int64](child, 16)
+	stale[0] = 42
+	child.Delete()
+
+	if kept[0] != 1 {
+		t.Errorf("Parent allocation before the child should be untouched, got %d", kept[0])
+	}
+	if uint64(stale[0]) != 0xDEDEDEDEDEDEDEDE {
+		t.Errorf("Expected poisoned child memory, got 0x%X", uint64(stale[0]))
+	}
+}
@@ -0,0 +1,67 @@
+//go:build arenadebug
+
+package arena_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+// allocateSmall and allocateLarge are distinct call sites for the profile to tell apart
+func allocateSmall(a *arena.Arena) {
+	for range 10 {
+		arena.MakeSlice[byte](a, 16, 16)
+	}
+}
+
+func allocateLarge(a *arena.Arena) {
+	arena.MakeSlice[byte](a, 4096, 4096)
+	arena.NewVec[int64](a).AppendSlice(make([]int64, 64)) // via a container method
+}
+
+func TestAllocProfile(t *testing.T) {
+	for _, kind := range []arena.Type{arena.BUMP, arena.SLAB, arena.BUDDY} {
+		t.Run(kind.String(), func(t *testing.T) {
+			a := arena.New(1, kind)
+			defer a.Delete()
+
+			allocateSmall(a)
+			allocateLarge(a)
+			a.Reset() // the profile is cumulative
+			allocateSmall(a)
+
+			totals := map[string]arena.AllocSiteStat{}
+			for site, s := range a.AllocProfile() {
+				if !strings.HasSuffix(site, ":"+strconv.Itoa(s.Line)) || !strings.HasSuffix(s.File, "profile_test.go") {
+					t.Errorf("Expected site %q in profile_test.go, got %+v", site, s)
+				}
+				name := s.Function[strings.LastIndex(s.Function, ".")+1:]
+				total := totals[name]
+				total.Count += s.Count
+				total.Bytes += s.Bytes
+				totals[name] = total
+			}
+			if len(totals) != 2 {
+				t.Fatalf("Expected exactly two attributed functions, got %+v", totals)
+			}
+			if s := totals["allocateSmall"]; s.Count != 20 || s.Bytes != 20*16 {
+				t.Errorf("Expected allocateSmall to be charged 20 allocs of 16 bytes, got %+v", s)
+			}
+			// The Vec may grow in place, which is not a new allocation
+			if s := totals["allocateLarge"]; s.Count < 2 || s.Bytes <= 4096 {
+				t.Errorf("Expected allocateLarge to be charged for the slice and the Vec, got %+v", s)
+			}
+
+			// A child charges its parent's profile
+			child := a.Child()
+			allocateSmall(child)
+			if s := child.AllocProfile(); len(s) != len(a.AllocProfile()) {
+				t.Errorf("Expected child to report the parent's profile")
+			}
+			child.Delete()
+		})
+	}
+}
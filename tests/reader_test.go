@@ -1,6 +1,7 @@
 package arena_test
 
 import (
+	"bytes"
 	"io"
 	"testing"
 
@@ -63,3 +64,163 @@ func TestReader(t *testing.T) {
 		t.Errorf("Reset: expected len 11, got %d", reader.Len())
 	}
 }
+
+func TestReaderRemaining(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	data := []byte("header:payload")
+	reader := arena.NewReader(a, data)
+
+	if string(reader.Remaining()) != "header:payload" || reader.Pos() != 0 {
+		t.Errorf("Initial Remaining = %q, Pos = %d", reader.Remaining(), reader.Pos())
+	}
+
+	buf := make([]byte, 7)
+	reader.Read(buf)
+	if reader.Pos() != 7 {
+		t.Errorf("Pos: expected 7, got %d", reader.Pos())
+	}
+	if string(reader.Remaining()) != "payload" {
+		t.Errorf("Remaining: expected 'payload', got %q", reader.Remaining())
+	}
+	if &reader.Remaining()[0] != &data[7] {
+		t.Error("Remaining should alias the buffer tail")
+	}
+	if string(reader.Consumed()) != "header:" {
+		t.Errorf("Consumed: expected 'header:', got %q", reader.Consumed())
+	}
+
+	// Delegate part of the remainder to a sub-parser, then skip what it consumed
+	if _, err := reader.Seek(3, io.SeekCurrent); err != nil {
+		t.Fatalf("Seek failed: %v", err)
+	}
+	if string(reader.Remaining()) != "load" || reader.Len() != 4 {
+		t.Errorf("After Seek: Remaining = %q, Len = %d", reader.Remaining(), reader.Len())
+	}
+
+	if _, err := reader.Seek(-1, io.SeekStart); err == nil {
+		t.Error("Seek to a negative position should fail")
+	}
+	if _, err := reader.Seek(5, io.SeekEnd); err != nil || len(reader.Remaining()) != 0 {
+		t.Errorf("Seek to end: Remaining = %q, err = %v", reader.Remaining(), err)
+	}
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	frames := [][]byte{
+		[]byte("hello"),
+		{},
+		bytes.Repeat([]byte{0xAB}, 300), // two-byte length prefix
+		[]byte("world"),
+	}
+
+	w := arena.NewWriter(a)
+	total := 0
+	for _, f := range frames {
+		n, err := w.WriteFrame(f)
+		if err != nil {
+			t.Fatalf("WriteFrame error: %v", err)
+		}
+		total += n
+	}
+	if total != w.Len() {
+		t.Errorf("Expected WriteFrame byte counts to sum to %d, got %d", w.Len(), total)
+	}
+
+	r := arena.NewReader(a, w.Bytes())
+	for i, want := range frames {
+		got, err := r.ReadFrame()
+		if err != nil {
+			t.Fatalf("ReadFrame %d error: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Frame %d: expected %q, got %q", i, want, got)
+		}
+	}
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Errorf("Expected io.EOF after the last frame, got %v", err)
+	}
+}
+
+func TestFrameTruncated(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	w := arena.NewWriter(a)
+	w.WriteFrame([]byte("complete"))
+	w.WriteFrame([]byte("truncated payload"))
+	data := w.Bytes()
+
+	r := arena.NewReader(a, data[:len(data)-3])
+	if got, err := r.ReadFrame(); err != nil || string(got) != "complete" {
+		t.Fatalf("Expected first frame, got %q, %v", got, err)
+	}
+	pos := r.Pos()
+	if _, err := r.ReadFrame(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF for a truncated frame, got %v", err)
+	}
+	if r.Pos() != pos {
+		t.Errorf("Expected position %d to be unchanged after a truncated frame, got %d", pos, r.Pos())
+	}
+
+	// A lone continuation byte is a truncated length prefix
+	r = arena.NewReader(a, []byte{0x80})
+	if _, err := r.ReadFrame(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF for a truncated prefix, got %v", err)
+	}
+}
+
+func TestMultiReader(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	parts := []string{"hello, ", "", "arena ", "world"}
+	readers := make([]*arena.Reader, 0, len(parts)+1)
+	for _, p := range parts {
+		readers = append(readers, arena.NewReader(a, []byte(p)))
+	}
+	readers = append(readers, arena.NewReader(a, nil))
+
+	mr := arena.NewMultiReader(a, readers...)
+	readers[0] = arena.NewReader(a, []byte("ignored"))
+	if mr.Arena() != a {
+		t.Errorf("Expected the MultiReader's arena to be a")
+	}
+	if mr.Len() != 18 {
+		t.Errorf("Expected Len 18, got %d", mr.Len())
+	}
+	data, err := io.ReadAll(mr)
+	if err != nil {
+		t.Fatalf("ReadAll error: %v", err)
+	}
+	if string(data) != "hello, arena world" {
+		t.Errorf("Expected %q, got %q", "hello, arena world", data)
+	}
+	if n, err := mr.Read(make([]byte, 4)); n != 0 || err != io.EOF {
+		t.Errorf("Expected 0, io.EOF after the last reader, got %d, %v", n, err)
+	}
+
+	// ReadByte crosses boundaries, including empty readers
+	mr = arena.NewMultiReader(a,
+		arena.NewReader(a, []byte("ab")),
+		arena.NewReader(a, nil),
+		arena.NewReader(a, []byte("c")),
+	)
+	var got []byte
+	for {
+		c, err := mr.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		got = append(got, c)
+	}
+	if string(got) != "abc" {
+		t.Errorf("Expected abc from ReadByte, got %q", got)
+	}
+
+	if _, err := arena.NewMultiReader(a).Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("Expected io.EOF from an empty MultiReader, got %v", err)
+	}
+}
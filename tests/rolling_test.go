@@ -0,0 +1,86 @@
+package arena_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestRollingHash(t *testing.T) {
+	text := "the quick brown fox jumps over the lazy dog, 日本語 \x00\xff bytes"
+	for _, window := range []int{1, 2, 3, 8, 16, len(text)} {
+		h := arena.NewRollingHash(window)
+		if h.WindowSize() != window {
+			t.Errorf("Expected WindowSize %d, got %d", window, h.WindowSize())
+		}
+		for i := range window {
+			h.Roll(text[i], 0)
+		}
+		for i := window; ; i++ {
+			if got, want := h.Value(), h.Sum(text[i-window:i]); got != want {
+				t.Fatalf("window %d at %d: expected rolling hash %x to match from-scratch %x", window, i-window, got, want)
+			}
+			if i == len(text) {
+				break
+			}
+			h.Roll(text[i], text[i-window])
+		}
+
+		h.Reset()
+		if h.Value() != 0 {
+			t.Errorf("Expected Reset to clear the hash, got %x", h.Value())
+		}
+	}
+
+	// Equal windows hash equally wherever they occur
+	h := arena.NewRollingHash(3)
+	if h.Sum("abc") != h.Sum(strings.Repeat("abc", 2)[3:]) {
+		t.Errorf("Expected equal windows to hash equally")
+	}
+	if h.Sum("abc") == h.Sum("acb") {
+		t.Errorf("Expected permuted windows to hash differently")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic for a non-positive window size")
+		}
+	}()
+	arena.NewRollingHash(0)
+}
+
+func TestIndexRolling(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	haystack := strings.Repeat("abracadabra ", 50) + "needle" + strings.Repeat("x", 10)
+	tests := []struct {
+		name     string
+		haystack string
+		needle   string
+	}{
+		{"empty needle", "abc", ""},
+		{"empty both", "", ""},
+		{"needle longer", "ab", "abc"},
+		{"prefix", "hello world", "hello"},
+		{"suffix", "hello world", "world"},
+		{"middle", "hello world", "o w"},
+		{"whole", "hello", "hello"},
+		{"absent", "hello world", "xyz"},
+		{"single byte", "hello", "l"},
+		{"repeated first match", "aaaaab", "aab"},
+		{"multibyte", "こんにちは世界", "世界"},
+		{"long", haystack, "needle"},
+		{"long absent", haystack, "needlex"},
+		{"overlapping", haystack, "abra abra"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := str.IndexRolling(tt.haystack, tt.needle), strings.Index(tt.haystack, tt.needle); got != want {
+				t.Errorf("IndexRolling(%q, %q) = %d, want %d", tt.haystack, tt.needle, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package arena_test
+
+import (
+	"testing"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestIsEmptyContainers(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	v := arena.NewVec[int](a)
+	m := arena.NewMap[string, int](a)
+	sl := arena.NewSkipList[int, string](a)
+
+	containers := []struct {
+		name string
+		c    arena.Sized
+		fill func()
+	}{
+		{"Vec", v, func() { v.Append(1) }},
+		{"Map", m, func() { m.Set("k", 1) }},
+		{"SkipList", sl, func() { sl.Insert(1, "v") }},
+	}
+	for _, tt := range containers {
+		t.Run(tt.name, func(t *testing.T) {
+			if !arena.IsEmpty(tt.c) {
+				t.Errorf("Expected new %s to be empty", tt.name)
+			}
+			tt.fill()
+			if arena.IsEmpty(tt.c) {
+				t.Errorf("Expected %s to be non-empty after insert", tt.name)
+			}
+		})
+	}
+
+	// Type parameters accept concrete container types directly
+	if arena.IsEmpty(v) || arena.IsEmpty(m) || arena.IsEmpty(sl) {
+		t.Errorf("Expected filled containers to be non-empty")
+	}
+
+	var c arena.Capacitied = arena.NewBufferSize(a, 64)
+	if !arena.IsEmpty(c) || c.Cap() != 64 {
+		t.Errorf("Expected empty Buffer with capacity 64, got Len %d Cap %d", c.Len(), c.Cap())
+	}
+}
@@ -1,6 +1,10 @@
 package arena_test
 
 import (
+	"iter"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/thebagchi/arena-go"
@@ -474,3 +478,412 @@ func TestSkipListManyElements(t *testing.T) {
 		prev = k
 	}
 }
+
+func TestSkipListCloneInto(t *testing.T) {
+	src := arena.New(4, arena.BUMP)
+	dst := arena.New(4, arena.BUMP)
+	defer dst.Delete()
+
+	sl := arena.NewSkipList[string, string](src)
+	for _, k := range []string{"delta", "alpha", "charlie", "bravo"} {
+		sl.Insert(src.MakeString(k), src.MakeString(k+"-value"))
+	}
+
+	clone := sl.CloneInto(dst)
+	src.Delete()
+
+	expected := []string{"alpha", "bravo", "charlie", "delta"}
+	i := 0
+	for k, v := range clone.All() {
+		if k != expected[i] || v != expected[i]+"-value" {
+			t.Errorf("Entry %d: expected %s=%s-value, got %s=%s", i, expected[i], expected[i], k, v)
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Errorf("Expected %d entries, got %d", len(expected), i)
+	}
+	if v, ok := clone.Search("charlie"); !ok || v != "charlie-value" {
+		t.Errorf("Search(charlie) = %q, %v", v, ok)
+	}
+}
+
+func TestSkipListCloneDeepInto(t *testing.T) {
+	src := arena.New(4, arena.BUMP)
+	dst := arena.New(4, arena.BUMP)
+	defer dst.Delete()
+
+	sl := arena.NewSkipList[int, *arena.Vec[string]](src)
+	for i := range 10 {
+		sl.Insert(i, arena.Ptr(src, *arena.NewVec(src, src.MakeString("v"+strconv.Itoa(i)))))
+	}
+
+	clone := arena.CloneSkipListDeepInto(sl, dst, func(v *arena.Vec[string], a *arena.Arena) *arena.Vec[string] {
+		c := arena.NewVec[string](a)
+		for _, s := range v.Slice() {
+			c.Append(a.MakeString(s))
+		}
+		return arena.Ptr(a, *c)
+	})
+	src.Delete()
+
+	i := 0
+	for k, v := range clone.All() {
+		if k != i {
+			t.Errorf("Entry %d: expected key %d, got %d", i, i, k)
+		}
+		if got, ok := v.Get(0); !ok || got != "v"+strconv.Itoa(i) {
+			t.Errorf("Entry %d: expected v%d, got %q (found=%v)", i, i, got, ok)
+		}
+		i++
+	}
+	if i != 10 {
+		t.Errorf("Expected 10 entries, got %d", i)
+	}
+}
+
+func TestSkipListEqual(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	build := func(pairs ...any) *arena.SkipList[string, int] {
+		sl := arena.NewSkipList[string, int](a)
+		for i := 0; i < len(pairs); i += 2 {
+			sl.Insert(pairs[i].(string), pairs[i+1].(int))
+		}
+		return sl
+	}
+	base := build("a", 1, "b", 2, "c", 3)
+
+	tests := []struct {
+		name  string
+		other *arena.SkipList[string, int]
+		want  bool
+	}{
+		{"same contents, different insert order", build("c", 3, "a", 1, "b", 2), true},
+		{"itself", base, true},
+		{"differing value", build("a", 1, "b", 20, "c", 3), false},
+		{"differing key", build("a", 1, "b", 2, "d", 3), false},
+		{"shorter", build("a", 1, "b", 2), false},
+		{"longer", build("a", 1, "b", 2, "c", 3, "d", 4), false},
+		{"empty", build(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := arena.SkipListEqual(base, tt.other); got != tt.want {
+				t.Errorf("Expected SkipListEqual = %v, got %v", tt.want, got)
+			}
+			if got := arena.SkipListEqual(tt.other, base); got != tt.want {
+				t.Errorf("Expected symmetric result %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if !arena.SkipListEqual(build(), build()) {
+		t.Errorf("Expected two empty lists to be equal")
+	}
+
+	// Custom value comparison, short-circuiting on the first difference
+	calls := 0
+	within := func(x, y int) bool { calls++; return x-y <= 1 && y-x <= 1 }
+	if !base.EqualFunc(build("a", 2, "b", 1, "c", 4), within) {
+		t.Errorf("Expected values within 1 to compare equal")
+	}
+	calls = 0
+	if base.EqualFunc(build("a", 9, "b", 2, "c", 3), within) || calls != 1 {
+		t.Errorf("Expected EqualFunc to stop after the first differing value, got %d calls", calls)
+	}
+
+	// Opposite-order comparisons running concurrently do not deadlock
+	other := build("a", 1, "b", 2, "c", 3)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				base.EqualFunc(other, func(x, y int) bool { return x == y })
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				other.Insert("z", i*1000+j)
+				other.Delete("z")
+				other.EqualFunc(base, func(x, y int) bool { return x == y })
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSkipListSearchRef(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	sl := arena.NewSkipList[int, [64]int](a)
+	sl.Insert(1, [64]int{7})
+
+	ref, ok := sl.SearchRef(1)
+	if !ok {
+		t.Fatal("SearchRef(1) should find the key")
+	}
+	ref[63] = 9
+	if v, _ := sl.Search(1); v[0] != 7 || v[63] != 9 {
+		t.Errorf("Search after SearchRef mutation: got %d %d", v[0], v[63])
+	}
+	if _, ok := sl.SearchRef(2); ok {
+		t.Error("SearchRef(2) should not find the key")
+	}
+}
+
+func TestSkipListGetByRankRange(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	// Leaderboard keyed by score; ranks follow key order
+	sl := arena.NewSkipList[int, string](a)
+	for i := 99; i >= 0; i-- {
+		sl.Insert(i*10, a.MakeString("player-"+strconv.Itoa(i)))
+	}
+
+	tests := []struct {
+		name      string
+		start     int
+		count     int
+		wantRanks []int
+	}{
+		{"first page", 0, 3, []int{0, 1, 2}},
+		{"middle page", 50, 4, []int{50, 51, 52, 53}},
+		{"truncated last page", 98, 5, []int{98, 99}},
+		{"past end", 100, 5, nil},
+		{"negative start", -1, 5, nil},
+		{"zero count", 10, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ranks []int
+			sl.GetByRankRange(tt.start, tt.count, func(rank, k int, v string) bool {
+				if k != rank*10 || v != "player-"+strconv.Itoa(rank) {
+					t.Errorf("Rank %d: expected %d=player-%d, got %d=%s", rank, rank*10, rank, k, v)
+				}
+				ranks = append(ranks, rank)
+				return true
+			})
+			if !slices.Equal(ranks, tt.wantRanks) {
+				t.Errorf("Expected ranks %v, got %v", tt.wantRanks, ranks)
+			}
+		})
+	}
+
+	// Paging by rank visits every entry once, in order
+	var seen []int
+	for start := 0; start < sl.Len(); start += 30 {
+		sl.GetByRankRange(start, 30, func(rank, k int, v string) bool {
+			seen = append(seen, rank)
+			return true
+		})
+	}
+	if len(seen) != 100 || !slices.IsSorted(seen) || seen[99] != 99 {
+		t.Errorf("Expected ranks 0..99 across pages, got %d ranks", len(seen))
+	}
+
+	// Early stop
+	n := 0
+	sl.GetByRankRange(0, 10, func(rank, k int, v string) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("Expected early stop after 2 entries, got %d", n)
+	}
+}
+
+func TestSkipListScanFrom(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	sl := arena.NewSkipList[int, int](a)
+	for i := 0; i < 100; i++ {
+		sl.Insert(i*2, i) // even keys 0..198
+	}
+
+	// Keyset pagination: every key is seen exactly once across pages
+	var (
+		seen   []int
+		cursor = 0
+		pages  = 0
+	)
+	for {
+		var page []int
+		sl.ScanFrom(cursor, 15, func(k, v int) bool {
+			page = append(page, k)
+			return true
+		})
+		if len(page) == 0 {
+			break
+		}
+		if len(page) > 15 {
+			t.Fatalf("Page %d exceeds limit: %d entries", pages, len(page))
+		}
+		seen = append(seen, page...)
+		cursor = page[len(page)-1] + 1
+		pages++
+	}
+	if pages != 7 || len(seen) != 100 {
+		t.Fatalf("Expected 100 keys in 7 pages, got %d keys in %d pages", len(seen), pages)
+	}
+	for i, k := range seen {
+		if k != i*2 {
+			t.Fatalf("Expected key %d at position %d, got %d", i*2, i, k)
+		}
+	}
+
+	// Start between keys positions at the ceiling
+	var first int
+	sl.ScanFrom(51, 1, func(k, v int) bool { first = k; return true })
+	if first != 52 {
+		t.Errorf("ScanFrom(51) expected first key 52, got %d", first)
+	}
+
+	// Unlimited scan and early stop
+	count := 0
+	sl.ScanFrom(190, 0, func(k, v int) bool { count++; return true })
+	if count != 5 {
+		t.Errorf("ScanFrom(190, 0) expected 5 entries, got %d", count)
+	}
+	count = 0
+	sl.ScanFrom(0, 0, func(k, v int) bool { count++; return count < 3 })
+	if count != 3 {
+		t.Errorf("Expected early stop after 3 entries, got %d", count)
+	}
+
+	// Past the end yields nothing
+	sl.ScanFrom(1000, 10, func(k, v int) bool {
+		t.Errorf("Unexpected key %d past the end", k)
+		return true
+	})
+}
+
+func TestSkipListAllFromRange(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	defer a.Delete()
+
+	sl := arena.NewSkipList[int, string](a)
+	for i := 10; i <= 100; i += 10 {
+		sl.Insert(i, a.MakeString(strconv.Itoa(i)))
+	}
+
+	collect := func(seq iter.Seq2[int, string]) []int {
+		var keys []int
+		for k, v := range seq {
+			if v != strconv.Itoa(k) {
+				t.Errorf("Expected value %q for key %d, got %q", strconv.Itoa(k), k, v)
+			}
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name string
+		seq  iter.Seq2[int, string]
+		want []int
+	}{
+		{"AllFrom existing key", sl.AllFrom(80), []int{80, 90, 100}},
+		{"AllFrom between keys", sl.AllFrom(75), []int{80, 90, 100}},
+		{"AllFrom before min", sl.AllFrom(-5), []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}},
+		{"AllFrom past max", sl.AllFrom(101), nil},
+		{"AllRange half-open", sl.AllRange(20, 50), []int{20, 30, 40}},
+		{"AllRange between keys", sl.AllRange(15, 45), []int{20, 30, 40}},
+		{"AllRange empty", sl.AllRange(50, 50), nil},
+		{"AllRange inverted", sl.AllRange(60, 20), nil},
+		{"AllRange out of range", sl.AllRange(200, 300), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(tt.seq); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Early termination
+	count := 0
+	for range sl.AllFrom(0) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Expected early break after 2 entries, got %d", count)
+	}
+}
+
+func TestSkipListCursor(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	sl := arena.NewSkipList[int, int](a)
+	for i := 0; i < 1000; i += 2 {
+		sl.Insert(i, i)
+	}
+
+	// Plain iteration sees every key in order
+	c := sl.Cursor()
+	n := 0
+	for k, v, ok := c.Next(); ok; k, v, ok = c.Next() {
+		if k != n*2 || v != k {
+			t.Fatalf("Expected key %d, got %d=%d", n*2, k, v)
+		}
+		n++
+	}
+	if n != 500 {
+		t.Errorf("Expected 500 entries, got %d", n)
+	}
+	if _, _, ok := c.Next(); ok {
+		t.Error("Expected exhausted cursor to stay exhausted")
+	}
+
+	// Iterate while a writer inserts and deletes concurrently
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			key := (i * 37) % 2000
+			if i%3 == 0 {
+				sl.Delete(key)
+			} else {
+				sl.Insert(key, key)
+			}
+		}
+	}()
+
+	c = sl.Cursor()
+	last, seen := -1, 0
+	for k, v, ok := c.Next(); ok; k, v, ok = c.Next() {
+		if k <= last {
+			t.Fatalf("Expected strictly increasing keys, got %d after %d", k, last)
+		}
+		if v != k {
+			t.Fatalf("Expected value %d for key %d, got %d", k, k, v)
+		}
+		last = k
+		seen++
+	}
+	close(done)
+	wg.Wait()
+	if seen == 0 {
+		t.Error("Expected the cursor to make progress under concurrent writes")
+	}
+}
@@ -0,0 +1,171 @@
+package arena_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestSlabAllocator(t *testing.T) {
+	const page = 4096
+
+	t.Run("blocks are distinct and aligned", func(t *testing.T) {
+		s := arena.NewSlabAllocator(64, page)
+		defer s.Delete()
+
+		seen := map[unsafe.Pointer]bool{}
+		for range page / 64 {
+			p := s.Alloc(40, 8)
+			if p == nil {
+				t.Fatalf("Expected a block, got nil")
+			}
+			if uintptr(p)%64 != 0 {
+				t.Errorf("Expected 64-aligned block, got %p", p)
+			}
+			if seen[p] {
+				t.Fatalf("Expected distinct blocks, got %p twice", p)
+			}
+			seen[p] = true
+			if !s.Owns(p) {
+				t.Errorf("Expected allocator to own %p", p)
+			}
+		}
+		if s.Owns(nil) || s.Owns(unsafe.Pointer(new(int))) {
+			t.Errorf("Expected Owns to reject nil and heap pointers")
+		}
+	})
+
+	t.Run("Remove reuses blocks", func(t *testing.T) {
+		s := arena.NewSlabAllocator(32, page)
+		defer s.Delete()
+
+		p := s.Alloc(32, 8)
+		q := s.Alloc(32, 8)
+		*(*uint64)(p) = 0xFFFF
+		s.Remove(p)
+		if r := s.Alloc(32, 8); r != p {
+			t.Errorf("Expected removed block %p to be reused, got %p", p, r)
+		}
+		if v := *(*uint64)(p); v != 0 {
+			t.Errorf("Expected reused block to be zeroed, got 0x%X", v)
+		}
+		if r := s.Alloc(32, 8); r == p || r == q {
+			t.Errorf("Expected a fresh block, got live block %p", r)
+		}
+	})
+
+	t.Run("Remove ignores foreign and interior pointers", func(t *testing.T) {
+		s := arena.NewSlabAllocator(32, page)
+		defer s.Delete()
+
+		p := s.Alloc(32, 8)
+		s.Remove(unsafe.Add(p, 16))
+		s.Remove(unsafe.Pointer(new(int)))
+		s.Remove(nil)
+		if q := s.Alloc(32, 8); q == p {
+			t.Errorf("Expected live block to survive bogus Removes")
+		}
+		s.Remove(p)
+		s.Remove(p) // double free is ignored
+		a, b := s.Alloc(32, 8), s.Alloc(32, 8)
+		if a != p || b == p {
+			t.Errorf("Expected removed block once, got %p and %p", a, b)
+		}
+	})
+
+	t.Run("grows by adding runs", func(t *testing.T) {
+		s := arena.NewSlabAllocator(256, page)
+		defer s.Delete()
+
+		var ptrs []unsafe.Pointer
+		for i := range 3 * page / 256 {
+			p := s.Alloc(256, 16)
+			*(*int)(p) = i
+			ptrs = append(ptrs, p)
+		}
+		for i, p := range ptrs {
+			if !s.Owns(p) {
+				t.Fatalf("Expected allocator to own block %d", i)
+			}
+			if v := *(*int)(p); v != i {
+				t.Fatalf("Expected block %d to hold %d, got %d", i, i, v)
+			}
+		}
+	})
+
+	t.Run("oversized requests", func(t *testing.T) {
+		s := arena.NewSlabAllocator(64, page)
+		defer s.Delete()
+
+		p := s.Alloc(3*page, 8)
+		if p == nil || !s.Owns(p) || !s.Owns(unsafe.Add(p, 3*page-1)) {
+			t.Fatalf("Expected an owned oversized allocation, got %p", p)
+		}
+		q := s.Alloc(16, 128)
+		if uintptr(q)%128 != 0 {
+			t.Errorf("Expected 128-aligned allocation, got %p", q)
+		}
+		s.Remove(p)
+		if s.Owns(p) {
+			t.Errorf("Expected oversized allocation to be released by Remove")
+		}
+	})
+
+	t.Run("Reset reuses runs", func(t *testing.T) {
+		s := arena.NewSlabAllocator(64, page)
+		defer s.Delete()
+
+		first := s.Alloc(64, 8)
+		for range 2 * page / 64 {
+			*(*byte)(s.Alloc(64, 8)) = 1
+		}
+		big := s.Alloc(2*page, 8)
+		s.Reset()
+		if s.Owns(big) {
+			t.Errorf("Expected Reset to release oversized allocations")
+		}
+		if p := s.Alloc(64, 8); p != first {
+			t.Errorf("Expected first block %p after Reset, got %p", first, p)
+		}
+		for range 2 * page / 64 {
+			if p := s.Alloc(64, 8); *(*byte)(p) != 0 {
+				t.Fatalf("Expected zeroed block after Reset, got %d", *(*byte)(p))
+			}
+		}
+	})
+}
+
+func TestSlabArena(t *testing.T) {
+	a := arena.New(1, arena.SLAB)
+	defer a.Delete()
+
+	type node struct {
+		key   int
+		value float64
+		next  *node
+	}
+	var head *node
+	for i := range 100 {
+		n := arena.Alloc[node](a)
+		n.key, n.value, n.next = i, float64(i)/2, head
+		head = n
+	}
+	for i := 99; i >= 0; i-- {
+		if head.key != i || head.value != float64(i)/2 {
+			t.Fatalf("Expected node %d, got %+v", i, *head)
+		}
+		if !a.Owns(unsafe.Pointer(head)) {
+			t.Fatalf("Expected arena to own node %d", i)
+		}
+		head = head.next
+	}
+
+	v := arena.MakeSlice[int](a, 0, 100)
+	for i := range 100 {
+		v = append(v, i)
+	}
+	if len(v) != 100 || v[99] != 99 {
+		t.Errorf("Expected 100 ints, got %d", len(v))
+	}
+}
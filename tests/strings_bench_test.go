@@ -1,6 +1,7 @@
 package arena_test
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
@@ -880,3 +881,48 @@ func BenchmarkArenaToValidUTF8Allocs(b *testing.B) {
 		a.Reset()
 	}
 }
+
+// ParseInt Benchmarks
+func BenchmarkStdParseInt(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = strconv.ParseInt("-1234567890", 10, 64)
+	}
+}
+
+func BenchmarkArenaParseInt(b *testing.B) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = str.ParseInt("-1234567890", 10, 64)
+	}
+}
+
+// IsASCII Benchmarks
+func BenchmarkArenaIsASCII(b *testing.B) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+	b.SetBytes(int64(len(benchLongStr)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = str.IsASCII(benchLongStr)
+	}
+}
+
+func BenchmarkNaiveIsASCII(b *testing.B) {
+	isASCII := func(s string) bool {
+		for i := 0; i < len(s); i++ {
+			if s[i] >= 0x80 {
+				return false
+			}
+		}
+		return true
+	}
+	b.SetBytes(int64(len(benchLongStr)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = isASCII(benchLongStr)
+	}
+}
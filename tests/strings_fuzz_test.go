@@ -0,0 +1,168 @@
+package arena_test
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	arena "github.com/thebagchi/arena-go"
+)
+
+// The fuzz targets compare Str against its strings package counterpart on arbitrary
+// input, including invalid UTF-8 and embedded NULs. Run one with e.g.
+//
+//	go test ./tests -run '^$' -fuzz FuzzSplit -fuzztime 30s
+
+func FuzzSplit(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"a,b,c", ","},
+		{"", ","},
+		{"abc", ""},
+		{"héllo wörld", "ö"},
+		{"a\x00b\x00", "\x00"},
+		{"\xff\xfe日本", ""},
+		{"aaaa", "aa"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	f.Fuzz(func(t *testing.T, s, sep string) {
+		defer a.Reset()
+		got, want := str.Split(s, sep), strings.Split(s, sep)
+		if !slices.Equal(got, want) {
+			t.Errorf("Split(%q, %q) = %q, want %q", s, sep, got, want)
+		}
+	})
+}
+
+func FuzzReplace(f *testing.F) {
+	for _, seed := range []struct {
+		s, old, new string
+		n           int
+	}{
+		{"hello world", "o", "0", -1},
+		{"hello", "l", "L", 1},
+		{"abc", "", "-", -1},
+		{"日本語", "", "|", 2},
+		{"a\x00b", "\x00", "", -1},
+		{"\xffx\xff", "\xff", "?", -1},
+		{"aaaa", "aa", "b", 0},
+	} {
+		f.Add(seed.s, seed.old, seed.new, seed.n)
+	}
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	f.Fuzz(func(t *testing.T, s, old, new string, n int) {
+		defer a.Reset()
+		if got, want := str.Replace(s, old, new, n), strings.Replace(s, old, new, n); got != want {
+			t.Errorf("Replace(%q, %q, %q, %d) = %q, want %q", s, old, new, n, got, want)
+		}
+	})
+}
+
+func FuzzToValidUTF8(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"valid", "?"},
+		{"a\xffb", "?"},
+		{"\xff\xfe\xfd", ""},
+		{"\xed\xa0\x80", "�"}, // encoded surrogate
+		{"日本\xe6\x97", "!"},   // truncated rune
+		{"\x00\xc0\x80", "?"}, // overlong NUL
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	f.Fuzz(func(t *testing.T, s, replacement string) {
+		defer a.Reset()
+		if got, want := str.ToValidUTF8(s, replacement), strings.ToValidUTF8(s, replacement); got != want {
+			t.Errorf("ToValidUTF8(%q, %q) = %q, want %q", s, replacement, got, want)
+		}
+	})
+}
+
+func FuzzLastIndexFunc(f *testing.F) {
+	for _, seed := range []string{"hello world", "日本 語", "x🙂y", "a\xff\xfeb", "\xe6\x97", ""} {
+		f.Add(seed)
+	}
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	preds := []func(rune) bool{
+		unicode.IsSpace,
+		func(r rune) bool { return r > 0x7f },
+		func(r rune) bool { return r == utf8.RuneError },
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for i, pred := range preds {
+			if got, want := str.LastIndexFunc(s, pred), strings.LastIndexFunc(s, pred); got != want {
+				t.Errorf("LastIndexFunc(%q, pred %d) = %d, want %d", s, i, got, want)
+			}
+		}
+	})
+}
+
+func FuzzMatch(f *testing.F) {
+	for _, seed := range [][2]string{{"*.log", "app.log"}, {"a*b?c", "axxbyc"}, {"*日?", "x日本"}, {"**", ""}, {"?", "é"}} {
+		f.Add(seed[0], seed[1])
+	}
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	f.Fuzz(func(t *testing.T, pattern, s string) {
+		if !utf8.ValidString(pattern) || !utf8.ValidString(s) {
+			t.Skip()
+		}
+		// Reference: translate the wildcard pattern into an anchored regexp
+		var expr strings.Builder
+		expr.WriteString("(?s)^")
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				expr.WriteString(".*")
+			case '?':
+				expr.WriteString(".")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		expr.WriteString("$")
+		want := regexp.MustCompile(expr.String()).MatchString(s)
+		if got := str.Match(pattern, s); got != want {
+			t.Errorf("Match(%q, %q) = %v, want %v", pattern, s, got, want)
+		}
+	})
+}
+
+func FuzzCase(f *testing.F) {
+	for _, seed := range []string{"Hello", "CAFÉ", "İstanbul", "größe", "ΑΘΉΝΑ", "a\xffB", ""} {
+		f.Add(seed)
+	}
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip() // strings.ToLower replaces invalid bytes; Str keeps them
+		}
+		if got, want := str.ToLower(s), strings.ToLower(s); got != want {
+			t.Errorf("ToLower(%q) = %q, want %q", s, got, want)
+		}
+		if got, want := str.ToUpper(s), strings.ToUpper(s); got != want {
+			t.Errorf("ToUpper(%q) = %q, want %q", s, got, want)
+		}
+	})
+}
@@ -1,7 +1,16 @@
 package arena_test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
 
 	arena "github.com/thebagchi/arena-go"
 )
@@ -372,6 +381,15 @@ func TestToLower(t *testing.T) {
 		{"mixed", "HeLLo", "hello"},
 		{"already lower", "hello", "hello"},
 		{"with numbers", "Hello123", "hello123"},
+		{"accented", "CAFÉ", "café"},
+		{"german", "GRÜSSE AUS ÖSTERREICH", "grüsse aus österreich"},
+		{"turkish dotted capital I", "İSTANBUL", "istanbul"},
+		{"greek", "ΑΘΉΝΑ", "αθήνα"},
+		{"cyrillic", "МОСКВА", "москва"},
+		{"upper after lower non-ASCII", "éÉ", "éé"},
+		{"non-ASCII already lower", "café ñ 日本", "café ñ 日本"},
+		{"invalid bytes kept", "A\xffB", "a\xffb"},
+		{"empty", "", ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -379,6 +397,12 @@ func TestToLower(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("ToLower(%q) = %q, want %q", tt.s, got, tt.want)
 			}
+			if utf8.ValidString(tt.s) && got != strings.ToLower(tt.s) {
+				t.Errorf("ToLower(%q) = %q, strings.ToLower gives %q", tt.s, got, strings.ToLower(tt.s))
+			}
+			if got == tt.s && tt.s != "" && unsafe.StringData(got) != unsafe.StringData(tt.s) {
+				t.Errorf("ToLower(%q) copied an unchanged string", tt.s)
+			}
 		})
 	}
 }
@@ -395,6 +419,14 @@ func TestToUpper(t *testing.T) {
 		{"mixed", "HeLLo", "HELLO"},
 		{"already upper", "HELLO", "HELLO"},
 		{"with numbers", "Hello123", "HELLO123"},
+		{"accented", "café", "CAFÉ"},
+		{"german umlauts", "größe über", "GRÖßE ÜBER"},
+		{"turkish dotless i", "ılık", "ILIK"},
+		{"greek", "αθήνα", "ΑΘΉΝΑ"},
+		{"cyrillic", "москва", "МОСКВА"},
+		{"non-ASCII already upper", "CAFÉ Ñ 日本", "CAFÉ Ñ 日本"},
+		{"invalid bytes kept", "a\xffb", "A\xffB"},
+		{"empty", "", ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -402,6 +434,12 @@ func TestToUpper(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("ToUpper(%q) = %q, want %q", tt.s, got, tt.want)
 			}
+			if utf8.ValidString(tt.s) && got != strings.ToUpper(tt.s) {
+				t.Errorf("ToUpper(%q) = %q, strings.ToUpper gives %q", tt.s, got, strings.ToUpper(tt.s))
+			}
+			if got == tt.s && tt.s != "" && unsafe.StringData(got) != unsafe.StringData(tt.s) {
+				t.Errorf("ToUpper(%q) copied an unchanged string", tt.s)
+			}
 		})
 	}
 }
@@ -428,6 +466,174 @@ func TestTitle(t *testing.T) {
 	}
 }
 
+func TestCapitalize(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	tests := []struct {
+		name  string
+		s     string
+		upper string
+		lower string
+	}{
+		{"empty", "", "", ""},
+		{"ascii", "hello world", "Hello world", "hello world"},
+		{"already capitalized", "Hello World", "Hello World", "hello World"},
+		{"identifier", "UserID", "UserID", "userID"},
+		{"multibyte first rune", "élan vital", "Élan vital", "élan vital"},
+		{"multibyte upper", "Ωmega", "Ωmega", "ωmega"},
+		{"non-letter first", "1st place", "1st place", "1st place"},
+		{"invalid utf8", "\xffabc", "\xffabc", "\xffabc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.Capitalize(tt.s); got != tt.upper {
+				t.Errorf("Capitalize(%q) = %q, want %q", tt.s, got, tt.upper)
+			}
+			if got := str.Uncapitalize(tt.s); got != tt.lower {
+				t.Errorf("Uncapitalize(%q) = %q, want %q", tt.s, got, tt.lower)
+			}
+		})
+	}
+
+	// Already in the desired case: the input is returned without copying
+	in := strings.Clone("Hello")
+	if got := str.Capitalize(in); unsafe.StringData(got) != unsafe.StringData(in) {
+		t.Errorf("Capitalize: expected zero-copy result for already capitalized input")
+	}
+	if n := testing.AllocsPerRun(100, func() { str.Capitalize(in) }); n != 0 {
+		t.Errorf("Capitalize: expected 0 allocs, got %v", n)
+	}
+	lower := strings.Clone("hello")
+	if got := str.Uncapitalize(lower); unsafe.StringData(got) != unsafe.StringData(lower) {
+		t.Errorf("Uncapitalize: expected zero-copy result for already lowercase input")
+	}
+
+	// A changed result lives in the arena
+	if got := str.Capitalize(lower); !a.Owns(unsafe.Pointer(unsafe.StringData(got))) {
+		t.Errorf("Capitalize: expected result to be allocated in the arena")
+	}
+}
+
+func TestSplitAfterFunc(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+
+	sentenceEnd := func(r rune) bool { return r == '.' || r == '!' || r == '?' }
+	anyOf := func(set string) func(rune) bool {
+		return func(r rune) bool { return strings.ContainsRune(set, r) }
+	}
+	tests := []struct {
+		name string
+		s    string
+		f    func(rune) bool
+		want []string
+	}{
+		{"sentences", "Hi. How are you? Great!", sentenceEnd, []string{"Hi.", " How are you?", " Great!"}},
+		{"trailing text", "One. Two", sentenceEnd, []string{"One.", " Two"}},
+		{"consecutive matches", "Wait?! Ok.", sentenceEnd, []string{"Wait?", "!", " Ok."}},
+		{"no match", "no punctuation", sentenceEnd, []string{"no punctuation"}},
+		{"empty", "", sentenceEnd, nil},
+		{"any of set", "a,b;c|d", anyOf(",;|"), []string{"a,", "b;", "c|", "d"}},
+		{"multibyte separators", "日本。東京、大阪", anyOf("。、"), []string{"日本。", "東京、", "大阪"}},
+		{"after digits", "ab1cd23e", unicode.IsDigit, []string{"ab1", "cd2", "3", "e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := str.SplitAfterFunc(tt.s, tt.f)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SplitAfterFunc(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if joined := strings.Join(got, ""); joined != tt.s {
+				t.Errorf("Expected segments to rejoin to %q, got %q", tt.s, joined)
+			}
+		})
+	}
+}
+
+func TestSplitRunes(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"multibyte", "héllo, 世界🙂", []string{"h", "é", "l", "l", "o", ",", " ", "世", "界", "🙂"}},
+		{"invalid bytes", "a\xff\xfe日", []string{"a", "\xff", "\xfe", "日"}},
+		{"truncated rune", "日\xe6\x97", []string{"日", "\xe6", "\x97"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := str.SplitRunes(tt.s)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("SplitRunes(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if want := strings.Split(tt.s, ""); !slices.Equal(got, want) {
+				t.Errorf("SplitRunes(%q) = %q, strings.Split = %q", tt.s, got, want)
+			}
+			// Each element shares the original backing
+			offset := 0
+			for i, r := range got {
+				if unsafe.StringData(r) != unsafe.StringData(tt.s[offset:]) {
+					t.Errorf("Element %d (%q) does not alias the input at offset %d", i, r, offset)
+				}
+				if utf8.ValidString(tt.s) && utf8.RuneCountInString(r) != 1 {
+					t.Errorf("Element %d (%q) is not a single rune", i, r)
+				}
+				offset += len(r)
+			}
+		})
+	}
+
+	// Split with an empty separator goes through SplitRunes
+	in := "日本語"
+	for i, r := range str.Split(in, "") {
+		if unsafe.StringData(r) != unsafe.StringData(in[i*3:]) {
+			t.Errorf("Split(%q, \"\")[%d] does not alias the input", in, i)
+		}
+	}
+}
+
+func TestHeredoc(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty", "", ""},
+		{"spaces", "\n    SELECT *\n    FROM t\n", "SELECT *\nFROM t\n"},
+		{"tabs", "\n\t\tif x {\n\t\t\treturn\n\t\t}\n", "if x {\n\treturn\n}\n"},
+		{"minimum indent", "\n      a\n    b\n        c", "  a\nb\n    c"},
+		{"indented closing line", "\n    a\n    b\n    ", "a\nb\n"},
+		{"blank lines", "\n  a\n\n     \n  b\n", "a\n\n\nb\n"},
+		{"mixed tabs and spaces", "\n\t  a\n\t b\n", " a\nb\n"},
+		{"crlf", "\r\n  a\r\n  b\r\n", "a\r\nb\r\n"},
+		{"no indent", "\nplain\ntext", "plain\ntext"},
+		{"only first newline stripped", "\n\n  a\n", "\na\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.Heredoc(tt.s); got != tt.want {
+				t.Errorf("Heredoc(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	// Nothing to dedent: the result is a substring of the input
+	in := strings.Clone("\nalready\nflush\n")
+	if got := str.Heredoc(in); unsafe.StringData(got) != unsafe.StringData(in[1:]) {
+		t.Errorf("Heredoc: expected zero-copy result when no indent is removed")
+	}
+}
+
 func TestSplit(t *testing.T) {
 	a := arena.New(1024, arena.BUMP)
 	str := arena.NewStr(a)
@@ -441,6 +647,7 @@ func TestSplit(t *testing.T) {
 	}{
 		{"simple", "a,b,c", ",", []string{"a", "b", "c"}},
 		{"empty sep", "hello", "", []string{"h", "e", "l", "l", "o"}},
+		{"empty sep multibyte", "aé日🙂", "", []string{"a", "é", "日", "🙂"}},
 		{"no match", "hello", ",", []string{"hello"}},
 		{"trailing sep", "a,b,", ",", []string{"a", "b", ""}},
 		{"leading sep", ",a,b", ",", []string{"", "a", "b"}},
@@ -523,6 +730,64 @@ func TestFields(t *testing.T) {
 	}
 }
 
+func TestFieldsUnicode(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name    string
+		s       string
+		ascii   []string
+		unicode []string
+	}{
+		{"ascii", " hello\tworld ", []string{"hello", "world"}, []string{"hello", "world"}},
+		{"no-break space", "hello\u00a0world", []string{"hello\u00a0world"}, []string{"hello", "world"}},
+		{"ideographic space", "\u3000日本\u3000語\u3000", []string{"\u3000日本\u3000語\u3000"}, []string{"日本", "語"}},
+		{"mixed", "a \u00a0b\u2003c", []string{"a", "\u00a0b\u2003c"}, []string{"a", "b", "c"}},
+		{"only unicode space", "\u00a0\u3000", []string{"\u00a0\u3000"}, nil},
+		{"empty", "", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.Fields(tt.s); !slices.Equal(got, tt.ascii) {
+				t.Errorf("Fields(%q) = %q, want %q", tt.s, got, tt.ascii)
+			}
+			if got := str.FieldsUnicode(tt.s); !slices.Equal(got, tt.unicode) {
+				t.Errorf("FieldsUnicode(%q) = %q, want %q", tt.s, got, tt.unicode)
+			}
+		})
+	}
+}
+
+func TestTrimSpaceUnicode(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"ascii", " \thello\n", "hello"},
+		{"no-break space", "\u00a0hello\u00a0", "hello"},
+		{"ideographic space", "\u3000日本語\u3000", "日本語"},
+		{"inner space kept", "\u00a0a\u00a0b\u00a0", "a\u00a0b"},
+		{"only unicode space", "\u00a0\u3000", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.TrimSpaceUnicode(tt.s); got != tt.want {
+				t.Errorf("TrimSpaceUnicode(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if got := str.TrimSpace(tt.s); got != tt.want {
+				t.Errorf("TrimSpace(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSplitJoinRoundtrip(t *testing.T) {
 	a := arena.New(1024, arena.BUMP)
 	str := arena.NewStr(a)
@@ -548,6 +813,107 @@ func TestSplitJoinRoundtrip(t *testing.T) {
 	}
 }
 
+func TestSplitIndices(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name    string
+		s       string
+		sep     string
+		offsets []int
+	}{
+		{"simple", "a,b,c", ",", []int{0, 2, 4}},
+		{"consecutive separators", "a,,bc,", ",", []int{0, 2, 3, 6}},
+		{"multi-byte separator", "key::value::", "::", []int{0, 5, 12}},
+		{"no separator", "hello", ",", []int{0}},
+		{"empty", "", ",", []int{0}},
+		{"empty separator", "añb", "", []int{0, 1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				offsets []int
+				fields  []string
+			)
+			for off, field := range str.SplitIndices(tt.s, tt.sep) {
+				if tt.s[off:off+len(field)] != field {
+					t.Errorf("Offset %d does not point at field %q", off, field)
+				}
+				offsets = append(offsets, off)
+				fields = append(fields, field)
+			}
+			if !slices.Equal(offsets, tt.offsets) {
+				t.Errorf("SplitIndices(%q, %q) offsets = %v, want %v", tt.s, tt.sep, offsets, tt.offsets)
+			}
+			if want := str.Split(tt.s, tt.sep); !slices.Equal(fields, want) && len(tt.s) > 0 {
+				t.Errorf("SplitIndices(%q, %q) fields = %q, want %q", tt.s, tt.sep, fields, want)
+			}
+		})
+	}
+
+	// Early termination
+	count := 0
+	for range str.SplitIndices("a,b,c", ",") {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected early break after 1 field, got %d", count)
+	}
+
+	if n := testing.AllocsPerRun(100, func() {
+		for range str.SplitIndices("a,b,c,d", ",") {
+		}
+	}); n != 0 {
+		t.Errorf("Expected 0 allocs, got %v", n)
+	}
+}
+
+func TestCountLines(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"empty", "", 0},
+		{"single line", "hello", 1},
+		{"trailing newline", "hello\n", 1},
+		{"two lines", "hello\nworld", 2},
+		{"two lines trailing newline", "hello\nworld\n", 2},
+		{"only newline", "\n", 1},
+		{"multiple empty lines", "\n\n\n", 3},
+		{"empty lines between", "a\n\n\nb", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.CountLines(tt.s); got != tt.want {
+				t.Errorf("CountLines(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+			// Must agree with the Lines iterator
+			n := 0
+			for range str.Lines(tt.s) {
+				n++
+			}
+			if n != tt.want {
+				t.Errorf("Lines(%q) yielded %d lines, want %d", tt.s, n, tt.want)
+			}
+		})
+	}
+
+	if n := str.CountRunes("héllo, 世界"); n != 9 {
+		t.Errorf("CountRunes: expected 9, got %d", n)
+	}
+	if n := str.CountRunes(""); n != 0 {
+		t.Errorf("CountRunes: expected 0 for empty string, got %d", n)
+	}
+}
+
 func TestLines(t *testing.T) {
 	a := arena.New(1, arena.BUMP)
 	str := arena.NewStr(a)
@@ -700,10 +1066,20 @@ func TestLastIndexFunc(t *testing.T) {
 		{"no space", "helloworld", func(r rune) bool { return r == ' ' }, -1},
 		{"last digit", "abc123def456", func(r rune) bool { return r >= '0' && r <= '9' }, 11},
 		{"no digit", "abc", func(r rune) bool { return r >= '0' && r <= '9' }, -1},
+		{"empty", "", func(r rune) bool { return true }, -1},
+		{"last multibyte rune matches", "aé日", func(r rune) bool { return r > 0x7f }, 3},
+		{"multibyte before ascii tail", "日本語abc", func(r rune) bool { return r == '本' }, 3},
+		{"four-byte rune", "x🙂y🙂", func(r rune) bool { return r == '🙂' }, 6},
+		{"first rune only", "é日本", func(r rune) bool { return r == 'é' }, 0},
+		{"ascii among multibyte", "日a本b語", func(r rune) bool { return r < 0x80 }, 7},
+		{"invalid byte", "a\xffé", func(r rune) bool { return r == utf8.RuneError }, 1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := str.LastIndexFunc(tt.s, tt.f)
+			if want := strings.LastIndexFunc(tt.s, tt.f); got != want {
+				t.Errorf("LastIndexFunc() = %v, strings.LastIndexFunc = %v", got, want)
+			}
 			if got != tt.want {
 				t.Errorf("LastIndexFunc() = %v, want %v", got, tt.want)
 			}
@@ -878,3 +1254,597 @@ func TestTrimFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestQuoteUnquote(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"plain", "hello", `"hello"`},
+		{"embedded quotes", `say "hi"`, `"say \"hi\""`},
+		{"backslash", `C:\path`, `"C:\\path"`},
+		{"tab and newline", "a\tb\nc", `"a\tb\nc"`},
+		{"control char", "x\x01y", `"x\x01y"`},
+		{"unicode", "héllo, 世界", `"héllo, 世界"`},
+		{"empty", "", `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := str.Quote(tt.s)
+			if got != tt.want {
+				t.Errorf("Quote(%q) = %s, want %s", tt.s, got, tt.want)
+			}
+			back, err := str.Unquote(got)
+			if err != nil {
+				t.Fatalf("Unquote(%s) failed: %v", got, err)
+			}
+			if back != tt.s {
+				t.Errorf("Unquote(Quote(%q)) = %q", tt.s, back)
+			}
+		})
+	}
+
+	for _, bad := range []string{``, `"`, `"abc`, `'abc'`, `"a"b"`, `"bad \q escape"`, "\"line\nbreak\""} {
+		if _, err := str.Unquote(bad); err == nil {
+			t.Errorf("Unquote(%q): expected error", bad)
+		}
+	}
+	if got, err := str.Unquote("`raw \\n`"); err != nil || got != `raw \n` {
+		t.Errorf("Unquote(raw) = %q, %v", got, err)
+	}
+}
+
+func TestTrimQuotes(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"double quotes", `"value"`, "value"},
+		{"single quotes", `'value'`, "value"},
+		{"only one pair removed", `""nested""`, `"nested"`},
+		{"escapes kept", `"say \"hi\""`, `say \"hi\"`},
+		{"empty quoted", `""`, ""},
+		{"mismatched", `"value'`, `"value'`},
+		{"leading only", `"value`, `"value`},
+		{"trailing only", `value'`, `value'`},
+		{"unquoted", "value", "value"},
+		{"single char quote", `"`, `"`},
+		{"single char", "x", "x"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.TrimQuotes(tt.s); got != tt.want {
+				t.Errorf("TrimQuotes(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteSingle(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"hello", `'hello'`},
+		{"it's", `'it'\''s'`},
+		{"", `''`},
+		{`$HOME "x"`, `'$HOME "x"'`},
+	}
+	for _, tt := range tests {
+		if got := str.QuoteSingle(tt.s); got != tt.want {
+			t.Errorf("QuoteSingle(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSplitMap(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	tests := []struct {
+		name string
+		s    string
+		want map[string]string
+	}{
+		{"basic", "a=1,b=2,c=", map[string]string{"a": "1", "b": "2", "c": ""}},
+		{"duplicate keys", "a=1,a=2", map[string]string{"a": "2"}},
+		{"missing kv separator", "a,b=2", map[string]string{"a": "", "b": "2"}},
+		{"trailing separators", "a=1,,b=2,", map[string]string{"a": "1", "b": "2"}},
+		{"value contains kv separator", "q=x=y", map[string]string{"q": "x=y"}},
+		{"empty", "", map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := str.SplitMap(tt.s, ",", "=")
+			if m.Len() != len(tt.want) {
+				t.Fatalf("SplitMap(%q) has %d entries, want %d", tt.s, m.Len(), len(tt.want))
+			}
+			for k, v := range tt.want {
+				if got, ok := m.Get(k); !ok || got != v {
+					t.Errorf("SplitMap(%q)[%q] = %q (found=%v), want %q", tt.s, k, got, ok, v)
+				}
+			}
+		})
+	}
+
+	// An empty pair separator must not loop forever: the whole string is one pair
+	m := str.SplitMap("a=1,b=2", "", "=")
+	if got, ok := m.Get("a"); m.Len() != 1 || !ok || got != "1,b=2" {
+		t.Errorf("SplitMap with empty pairSep: expected {a: 1,b=2}, got %d entries, a=%q", m.Len(), got)
+	}
+}
+
+func TestIndexRuneCutRune(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	tests := []struct {
+		name   string
+		s      string
+		r      rune
+		index  int
+		before string
+		after  string
+	}{
+		{"ascii", "key=value", '=', 3, "key", "value"},
+		{"multibyte separator", "a→b→c", '→', 1, "a", "b→c"},
+		{"multibyte content", "日本語", '本', 3, "日", "語"},
+		// 'ℵ' (E2 84 B5) shares its leading byte with '→' (E2 86 92)
+		{"no partial match", "ℵ→x", '→', 3, "ℵ", "x"},
+		// '€' (E2 82 AC) bytes do not occur at a rune boundary in "ₒ" (E2 82 92)
+		{"not found", "ₒₒ", '€', -1, "ₒₒ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.IndexRune(tt.s, tt.r); got != tt.index {
+				t.Errorf("IndexRune(%q, %q) = %d, want %d", tt.s, tt.r, got, tt.index)
+			}
+			before, after, found := str.CutRune(tt.s, tt.r)
+			if found != (tt.index >= 0) || before != tt.before || after != tt.after {
+				t.Errorf("CutRune(%q, %q) = %q, %q, %v, want %q, %q", tt.s, tt.r, before, after, found, tt.before, tt.after)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"no escapes", "plain text", "plain text"},
+		{"colors", "\x1b[31mred\x1b[0m and \x1b[1;32mbold green\x1b[m", "red and bold green"},
+		{"cursor movement", "a\x1b[2Kb\x1b[10;20Hc\x1b[?25l", "abc"},
+		{"osc title", "\x1b]0;window title\x07prompt$ ", "prompt$ "},
+		{"osc with st", "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"two byte escapes", "\x1b7saved\x1b8\x1b(Bascii", "savedascii"},
+		{"truncated csi", "text\x1b[31", "text"},
+		{"lone escape", "end\x1b", "end"},
+		{"unicode kept", "\x1b[34m世界\x1b[0m", "世界"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.StripANSI(tt.s); got != tt.want {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	tests := []struct {
+		s       string
+		base    int
+		bitSize int
+	}{
+		{"0", 10, 64},
+		{"12345", 10, 64},
+		{"-12345", 10, 64},
+		{"+42", 10, 0},
+		{"999999999999999999", 10, 64},
+		{"9223372036854775807", 10, 64},
+		{"9223372036854775808", 10, 64},
+		{"-9223372036854775808", 10, 64},
+		{"127", 10, 8},
+		{"128", 10, 8},
+		{"-128", 10, 8},
+		{"-129", 10, 8},
+		{"2147483648", 10, 32},
+		{"", 10, 64},
+		{"-", 10, 64},
+		{"12a", 10, 64},
+		{"1_000", 0, 64},
+		{"ff", 16, 64},
+		{"0x1F", 0, 64},
+	}
+	for _, tt := range tests {
+		got, err := str.ParseInt(tt.s, tt.base, tt.bitSize)
+		want, wantErr := strconv.ParseInt(tt.s, tt.base, tt.bitSize)
+		if got != want || (err == nil) != (wantErr == nil) || (err != nil && err.Error() != wantErr.Error()) {
+			t.Errorf("ParseInt(%q, %d, %d) = %d, %v; strconv gives %d, %v", tt.s, tt.base, tt.bitSize, got, err, want, wantErr)
+		}
+	}
+
+	// Works on zero-copy fields without allocating
+	fields := str.Split("10,20,30", ",")
+	sum := int64(0)
+	for _, f := range fields {
+		n, err := str.ParseInt(f, 10, 64)
+		if err != nil {
+			t.Fatalf("ParseInt(%q) failed: %v", f, err)
+		}
+		sum += n
+	}
+	if sum != 60 {
+		t.Errorf("Expected sum 60, got %d", sum)
+	}
+	if n := testing.AllocsPerRun(100, func() { str.ParseInt(fields[1], 10, 64) }); n != 0 {
+		t.Errorf("ParseInt: expected 0 allocs, got %v", n)
+	}
+
+	if f, err := str.ParseFloat("3.25", 64); err != nil || f != 3.25 {
+		t.Errorf("ParseFloat(3.25) = %v, %v", f, err)
+	}
+	if _, err := str.ParseFloat("abc", 64); err == nil {
+		t.Error("ParseFloat(abc) should fail")
+	}
+}
+
+func TestHexBase64(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	binary := make([]byte, 256)
+	for i := range binary {
+		binary[i] = byte(i)
+	}
+	inputs := [][]byte{nil, {0}, []byte("f"), []byte("fo"), []byte("foo"), []byte("hello, arena"), binary}
+
+	for _, data := range inputs {
+		h := str.HexEncode(data)
+		if want := hex.EncodeToString(data); h != want {
+			t.Errorf("HexEncode(%x) = %q, want %q", data, h, want)
+		}
+		if decoded, err := str.HexDecode(h); err != nil || !bytes.Equal(decoded, data) {
+			t.Errorf("HexDecode(%q) = %x, %v; want %x", h, decoded, err, data)
+		}
+
+		b := str.Base64Encode(data)
+		if want := base64.StdEncoding.EncodeToString(data); b != want {
+			t.Errorf("Base64Encode(%x) = %q, want %q", data, b, want)
+		}
+		if decoded, err := str.Base64Decode(b); err != nil || !bytes.Equal(decoded, data) {
+			t.Errorf("Base64Decode(%q) = %x, %v; want %x", b, decoded, err, data)
+		}
+	}
+
+	for _, bad := range []string{"abc", "zz", "0g"} {
+		_, err := str.HexDecode(bad)
+		_, want := hex.DecodeString(bad)
+		if err == nil || err.Error() != want.Error() {
+			t.Errorf("HexDecode(%q) error = %v, want %v", bad, err, want)
+		}
+	}
+	for _, bad := range []string{"Zm9", "Zm9v!", "===="} {
+		_, err := str.Base64Decode(bad)
+		_, want := base64.StdEncoding.DecodeString(bad)
+		if err == nil || err.Error() != want.Error() {
+			t.Errorf("Base64Decode(%q) error = %v, want %v", bad, err, want)
+		}
+	}
+}
+
+func TestIsASCII(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name string
+		s    string
+		want bool
+	}{
+		{"empty", "", true},
+		{"short ascii", "hello", true},
+		{"long ascii", "the quick brown fox jumps over the lazy dog\x7f", true},
+		{"multibyte", "héllo", false},
+		{"multibyte in first word", "日本語 and more ascii text", false},
+		{"multibyte in tail", "exactly sixteen!é", false},
+		{"invalid utf8", "abcdefgh\xff", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.IsASCII(tt.s); got != tt.want {
+				t.Errorf("IsASCII(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	// A non-ASCII byte is detected at every offset
+	for i := 0; i < 20; i++ {
+		b := []byte(strings.Repeat("a", 20))
+		b[i] = 0x80
+		if str.IsASCII(string(b)) {
+			t.Errorf("IsASCII missed 0x80 at offset %d", i)
+		}
+	}
+}
+
+func TestTruncateBytes(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	tests := []struct {
+		name string
+		s    string
+		max  int
+		want string
+	}{
+		{"fits", "hello", 10, "hello"},
+		{"exact length", "hello", 5, "hello"},
+		{"ascii cut", "hello", 3, "hel"},
+		{"zero", "hello", 0, ""},
+		{"negative", "hello", -1, ""},
+		{"mid two-byte rune", "café", 4, "caf"},
+		{"at boundary before multibyte", "café", 3, "caf"},
+		{"mid emoji", "ok🙂!", 4, "ok"},
+		{"mid emoji last byte", "ok🙂!", 5, "ok"},
+		{"after emoji", "ok🙂!", 6, "ok🙂"},
+		{"first rune too wide", "🙂", 2, ""},
+		{"invalid bytes", "ab\xff\xfecd", 3, "ab\xff"},
+		{"stray continuation bytes", "a\x80\x80\x80\x80b", 3, "a\x80\x80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := str.TruncateBytes(tt.s, tt.max)
+			if got != tt.want {
+				t.Errorf("TruncateBytes(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+			}
+			if utf8.ValidString(tt.s) && !utf8.ValidString(got) {
+				t.Errorf("TruncateBytes(%q, %d) produced invalid UTF-8 %q", tt.s, tt.max, got)
+			}
+		})
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	tests := []struct {
+		name   string
+		s      string
+		max    int
+		want   string
+		middle string
+	}{
+		{"under", "hello", 10, "hello", "hello"},
+		{"exact", "hello", 5, "hello", "hello"},
+		{"over", "hello world", 8, "hello w…", "hell…rld"},
+		{"max one", "hello", 1, "…", "…"},
+		{"max zero", "hello", 0, "", ""},
+		{"empty", "", 3, "", ""},
+		{"multibyte", "日本語のテキスト", 5, "日本語の…", "日本…スト"},
+		{"multibyte exact", "héllo", 5, "héllo", "héllo"},
+		{"path", "/usr/local/lib/arena/arena.go", 15, "/usr/local/lib…", "/usr/lo…rena.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := str.Abbreviate(tt.s, tt.max)
+			if got != tt.want {
+				t.Errorf("Abbreviate(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); tt.max > 0 && n > tt.max {
+				t.Errorf("Abbreviate(%q, %d) has %d runes", tt.s, tt.max, n)
+			}
+			got = str.AbbreviateMiddle(tt.s, tt.max)
+			if got != tt.middle {
+				t.Errorf("AbbreviateMiddle(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.middle)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("AbbreviateMiddle(%q, %d) produced invalid UTF-8", tt.s, tt.max)
+			}
+		})
+	}
+}
+
+func TestBytesVariants(t *testing.T) {
+	a := arena.New(4, arena.BUMP)
+	str := arena.NewStr(a)
+	defer a.Delete()
+
+	inputs := []struct {
+		s   string
+		sub string
+	}{
+		{"hello world", "o"},
+		{"a,b,,c,", ","},
+		{"  spaced \t out\n", " "},
+		{"key::value", "::"},
+		{"héllo, 世界", ""},
+		{"", ","},
+		{"no match", "xyz"},
+	}
+	toStrings := func(parts [][]byte) []string {
+		out := make([]string, 0, len(parts))
+		for _, p := range parts {
+			out = append(out, string(p))
+		}
+		return out
+	}
+
+	for _, in := range inputs {
+		b, sub := []byte(in.s), []byte(in.sub)
+		if got, want := str.ContainsBytes(b, sub), str.Contains(in.s, in.sub); got != want {
+			t.Errorf("ContainsBytes(%q, %q) = %v, want %v", in.s, in.sub, got, want)
+		}
+		if got, want := str.IndexBytes(b, sub), str.Index(in.s, in.sub); got != want {
+			t.Errorf("IndexBytes(%q, %q) = %d, want %d", in.s, in.sub, got, want)
+		}
+		if got, want := str.HasPrefixBytes(b, sub), str.HasPrefix(in.s, in.sub); got != want {
+			t.Errorf("HasPrefixBytes(%q, %q) = %v, want %v", in.s, in.sub, got, want)
+		}
+		if got, want := str.HasSuffixBytes(b, sub), str.HasSuffix(in.s, in.sub); got != want {
+			t.Errorf("HasSuffixBytes(%q, %q) = %v, want %v", in.s, in.sub, got, want)
+		}
+		if got, want := string(str.TrimSpaceBytes(b)), str.TrimSpace(in.s); got != want {
+			t.Errorf("TrimSpaceBytes(%q) = %q, want %q", in.s, got, want)
+		}
+		if got, want := toStrings(str.SplitBytes(b, sub)), str.Split(in.s, in.sub); !slices.Equal(got, want) {
+			t.Errorf("SplitBytes(%q, %q) = %q, want %q", in.s, in.sub, got, want)
+		}
+		if got, want := toStrings(str.FieldsBytes(b)), str.Fields(in.s); !slices.Equal(got, want) {
+			t.Errorf("FieldsBytes(%q) = %q, want %q", in.s, got, want)
+		}
+	}
+
+	// Parts are capacity-limited views into the input
+	data := []byte("ab,cd")
+	parts := str.SplitBytes(data, []byte(","))
+	_ = append(parts[0], 'X')
+	if string(data) != "ab,cd" {
+		t.Errorf("Appending to a part overwrote the input: %q", data)
+	}
+	if &parts[1][0] != &data[3] {
+		t.Error("Expected SplitBytes parts to alias the input")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	tests := []struct {
+		name    string
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"literal", "hello", "hello", true},
+		{"literal mismatch", "hello", "help", false},
+		{"literal longer than input", "hello", "hell", false},
+		{"empty both", "", "", true},
+		{"empty pattern", "", "x", false},
+		{"star matches empty", "*", "", true},
+		{"star matches all", "*", "anything", true},
+		{"leading star", "*.log", "app.log", true},
+		{"leading star mismatch", "*.log", "app.txt", false},
+		{"trailing star", "app.*", "app.log", true},
+		{"trailing star empty rest", "app*", "app", true},
+		{"middle star", "user_*_config", "user_42_config", true},
+		{"middle star empty", "user_*_config", "user__config", true},
+		{"middle star mismatch", "user_*_config", "user_42_conf", false},
+		{"star backtracks", "*ab", "aab", true},
+		{"several stars", "a*b*c", "a-x-b-y-c", true},
+		{"several stars order", "a*b*c", "a-c-b", false},
+		{"consecutive stars", "a**b", "axyb", true},
+		{"question", "v?.?", "v1.2", true},
+		{"question needs a rune", "a?", "a", false},
+		{"question too short", "???", "ab", false},
+		{"question multibyte", "caf?", "café", true},
+		{"question one rune not bytes", "??", "é", false},
+		{"star multibyte", "*é*", "résumé", true},
+		{"literal multibyte", "日本*", "日本語", true},
+		{"star and question", "*?x", "abx", true},
+		{"star and question too short", "*??x", "ax", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := str.Match(tt.pattern, tt.s); got != tt.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+			}
+		})
+	}
+
+	// Pathological patterns must not backtrack exponentially
+	long := strings.Repeat("a", 100000)
+	for _, pattern := range []string{"*a*b*c", "*a*a*a*a*a*a*a*b", "a*a*a*a*a*a*a*a*a*a*"} {
+		want := !strings.Contains(pattern, "b") && !strings.Contains(pattern, "c")
+		if got := str.Match(pattern, long); got != want {
+			t.Errorf("Match(%q, long) = %v, want %v", pattern, got, want)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { str.Match("user_*_config?", "user_42_configs") }); allocs != 0 {
+		t.Errorf("Expected 0 allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	s := strings.Repeat("a", 1000) + ".log"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		str.Match("*a*a*.log", s)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	str := arena.NewStr(a)
+	tests := []struct {
+		name        string
+		s           string
+		open, close string
+		want        string
+		found       bool
+		all         []string
+	}{
+		{"tag", "<b>value</b>", "<b>", "</b>", "value", true, []string{"value"}},
+		{"quotes", `key="value"`, `"`, `"`, "value", true, []string{"value"}},
+		{"empty content", "a()b", "(", ")", "", true, []string{""}},
+		{"missing open", "value)", "(", ")", "", false, nil},
+		{"missing close", "(value", "(", ")", "", false, nil},
+		{"close before open", ")x(", "(", ")", "", false, nil},
+		{"multiple", "[a] [bb] [] [c", "[", "]", "a", true, []string{"a", "bb", ""}},
+		{"not nested", "((a))", "(", ")", "(a", true, []string{"(a"}},
+		{"same delimiter", `"a" "b"`, `"`, `"`, "a", true, []string{"a", "b"}},
+		{"multibyte", "«日本»«語»", "«", "»", "日本", true, []string{"日本", "語"}},
+		{"empty open", "ab;cd;", "", ";", "ab", true, []string{"ab", "cd"}},
+		{"empty delimiters", "ab", "", "", "", true, []string{"", "", ""}},
+		{"empty input", "", "(", ")", "", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := str.Between(tt.s, tt.open, tt.close)
+			if got != tt.want || found != tt.found {
+				t.Errorf("Between(%q, %q, %q) = (%q, %v), want (%q, %v)", tt.s, tt.open, tt.close, got, found, tt.want, tt.found)
+			}
+			if found && got != "" && !strings.Contains(tt.s, got) {
+				t.Errorf("Between result %q is not a substring", got)
+			}
+			all := str.BetweenAll(tt.s, tt.open, tt.close)
+			if !slices.Equal(all, tt.all) {
+				t.Errorf("BetweenAll(%q, %q, %q) = %q, want %q", tt.s, tt.open, tt.close, all, tt.all)
+			}
+			if len(all) > 0 && !arena.OwnsSlice(a, all) {
+				t.Errorf("Expected BetweenAll result to be allocated in the arena")
+			}
+		})
+	}
+
+	// Results alias the input
+	s := "id=<42>"
+	if v, _ := str.Between(s, "<", ">"); unsafe.StringData(v) != unsafe.StringData(s[4:]) {
+		t.Errorf("Expected Between to return a zero-copy substring")
+	}
+}
@@ -0,0 +1,73 @@
+package arena_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestSymbolTable(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	syms := arena.NewSymbolTable(a)
+	tokens := []string{"let", "x", "=", "x", "+", "y", "let", "y"}
+
+	var ids []int
+	for _, tok := range tokens {
+		ids = append(ids, syms.Intern(tok))
+	}
+	expected := []int{0, 1, 2, 1, 3, 4, 0, 4}
+	for i := range tokens {
+		if ids[i] != expected[i] {
+			t.Errorf("Intern(%q) = %d, want %d", tokens[i], ids[i], expected[i])
+		}
+	}
+	if syms.Len() != 5 {
+		t.Errorf("Expected 5 symbols, got %d", syms.Len())
+	}
+
+	for i, tok := range tokens {
+		name := syms.Name(ids[i])
+		if name != tok {
+			t.Errorf("Name(%d) = %q, want %q", ids[i], name, tok)
+		}
+		if !a.Owns(unsafe.Pointer(unsafe.StringData(name))) {
+			t.Errorf("Expected name %q to be copied into the arena", name)
+		}
+	}
+	if syms.Name(99) != "" || syms.Name(-1) != "" {
+		t.Error("Expected empty name for unknown IDs")
+	}
+	if id, ok := syms.Lookup("y"); !ok || id != 4 {
+		t.Errorf("Lookup(y) = %d, %v; want 4, true", id, ok)
+	}
+	if _, ok := syms.Lookup("z"); ok || syms.Len() != 5 {
+		t.Error("Lookup should not intern unknown names")
+	}
+
+	// Concurrent interning assigns each name exactly one ID
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				syms.Intern("sym" + strconv.Itoa(i))
+			}
+		}()
+	}
+	wg.Wait()
+	if syms.Len() != 205 {
+		t.Errorf("Expected 205 symbols after concurrent interning, got %d", syms.Len())
+	}
+	for i := 0; i < 200; i++ {
+		name := "sym" + strconv.Itoa(i)
+		if id, _ := syms.Lookup(name); syms.Name(id) != name {
+			t.Errorf("Round trip failed for %q (id %d)", name, id)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package arena_test
 
 import (
+	"cmp"
+	"math/rand"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"unsafe"
 
 	"github.com/thebagchi/arena-go"
 )
@@ -63,6 +69,48 @@ func TestVecAppendSlice(t *testing.T) {
 	}
 }
 
+func TestVecCloneTo(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	long := arena.NewVec[int](a, 1, 2, 3, 4, 5, 6, 7, 8)
+	short := arena.NewVec[int](a, 9, 10)
+	empty := arena.NewVec[int](a)
+
+	dst := long.CloneTo(nil)
+	if !slices.Equal(dst, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Fatalf("Expected long contents, got %v", dst)
+	}
+	backing := &dst[:1][0]
+
+	for _, tc := range []struct {
+		vec  *arena.Vec[int]
+		want []int
+	}{
+		{short, []int{9, 10}},
+		{empty, []int{}},
+		{long, []int{1, 2, 3, 4, 5, 6, 7, 8}},
+	} {
+		dst = tc.vec.CloneTo(dst)
+		if !slices.Equal(dst, tc.want) {
+			t.Errorf("Expected %v, got %v", tc.want, dst)
+		}
+		if &dst[:1][0] != backing {
+			t.Error("Expected dst backing array to be reused")
+		}
+	}
+
+	// The clone is independent of the Vec
+	dst[0] = 100
+	if v, _ := long.Get(0); v != 1 {
+		t.Errorf("Expected Vec unchanged after modifying clone, got %d", v)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { dst = long.CloneTo(dst) }); n != 0 {
+		t.Errorf("Expected 0 allocs when reusing dst, got %v", n)
+	}
+}
+
 func TestVecSSO(t *testing.T) {
 	a := arena.New(1024, arena.BUMP)
 	defer a.Delete()
@@ -323,6 +371,63 @@ func BenchmarkVecAppendSlice(b *testing.B) {
 	}
 }
 
+// Front insertion of 1000 elements: repeated Insert(0) shifts on every call, while
+// appending and reversing once (the alternative the Prepend docs suggest) is linear
+func BenchmarkVecInsertFront(b *testing.B) {
+	a := arena.New(1024*1024, arena.BUMP)
+	defer a.Delete()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		slice := arena.NewVec[int](a)
+		for j := 0; j < 1000; j++ {
+			slice.Insert(0, j)
+		}
+		a.Reset()
+	}
+}
+
+func BenchmarkVecAppendReverse(b *testing.B) {
+	a := arena.New(1024*1024, arena.BUMP)
+	defer a.Delete()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		slice := arena.NewVec[int](a)
+		for j := 0; j < 1000; j++ {
+			slice.AppendOne(j)
+		}
+		slice.Reverse()
+		a.Reset()
+	}
+}
+
+func BenchmarkVecClone(b *testing.B) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	v := arena.NewVec[int](a)
+	for i := range 1024 {
+		v.Append(i)
+	}
+
+	b.Run("Clone", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			snap := v.Clone()
+			_ = snap
+		}
+	})
+	b.Run("ClonePooled", func(b *testing.B) {
+		var pool sync.Pool
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			snap := v.ClonePooled(&pool)
+			pool.Put(&snap)
+		}
+	})
+}
+
 func BenchmarkVecIterate(b *testing.B) {
 	a := arena.New(1024*1024, arena.BUMP)
 	defer a.Delete()
@@ -350,3 +455,653 @@ func BenchmarkStandardSlice(b *testing.B) {
 		_ = slice // Use the slice to avoid SA4010
 	}
 }
+
+func TestVecFromSeq(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	squares := func(yield func(int) bool) {
+		for i := range 100 {
+			if !yield(i * i) {
+				return
+			}
+		}
+	}
+	vec := arena.VecFromSeq(a, squares)
+	if vec.Len() != 100 {
+		t.Fatalf("Expected length 100, got %d", vec.Len())
+	}
+	for i, v := range vec.Slice() {
+		if v != i*i {
+			t.Errorf("Expected vec[%d] = %d, got %d", i, i*i, v)
+		}
+	}
+	if !arena.OwnsSlice(a, vec.Slice()) {
+		t.Error("Collected elements should live in arena memory")
+	}
+
+	m := arena.NewMap[string, int](a)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	pairs := arena.VecFromSeq2(a, m.All())
+	if pairs.Len() != 3 {
+		t.Fatalf("Expected 3 pairs, got %d", pairs.Len())
+	}
+	for _, p := range pairs.Slice() {
+		if v, ok := m.Get(p.Key); !ok || v != p.Value {
+			t.Errorf("Pair %s=%d does not match map", p.Key, p.Value)
+		}
+	}
+}
+
+func TestVecPopNPeekN(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	stack := arena.NewVec[int](a)
+	stack.PushN(1, 2, 3, 4, 5)
+
+	peek, ok := stack.PeekN(2)
+	if !ok || !reflect.DeepEqual(peek, []int{4, 5}) || stack.Len() != 5 {
+		t.Errorf("PeekN(2) = %v, %v (len %d)", peek, ok, stack.Len())
+	}
+
+	top, ok := stack.PopN(2)
+	if !ok || !reflect.DeepEqual(top, []int{4, 5}) {
+		t.Errorf("PopN(2) = %v, %v", top, ok)
+	}
+	if !reflect.DeepEqual(stack.Slice(), []int{1, 2, 3}) {
+		t.Errorf("After PopN(2) expected [1 2 3], got %v", stack.Slice())
+	}
+
+	// Zero is a valid, empty pop
+	if none, ok := stack.PopN(0); !ok || len(none) != 0 || stack.Len() != 3 {
+		t.Errorf("PopN(0) = %v, %v (len %d)", none, ok, stack.Len())
+	}
+
+	// More than available fails without modifying the Vec
+	if _, ok := stack.PopN(4); ok || stack.Len() != 3 {
+		t.Errorf("PopN(4) should fail on length 3, len now %d", stack.Len())
+	}
+	if _, ok := stack.PeekN(-1); ok {
+		t.Error("PeekN(-1) should fail")
+	}
+
+	// Popping exactly all empties the Vec
+	all, ok := stack.PopN(3)
+	if !ok || !reflect.DeepEqual(all, []int{1, 2, 3}) || stack.Len() != 0 {
+		t.Errorf("PopN(3) = %v, %v (len %d)", all, ok, stack.Len())
+	}
+}
+
+func TestVecRemoveIndices(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	tests := []struct {
+		name     string
+		indices  []int
+		removed  int
+		expected []int
+	}{
+		{"Sorted", []int{0, 2, 4}, 3, []int{1, 3, 5}},
+		{"Unsorted", []int{5, 1, 3}, 3, []int{0, 2, 4}},
+		{"Duplicates", []int{2, 2, 2, 0}, 2, []int{1, 3, 4, 5}},
+		{"OutOfRange", []int{-1, 6, 100, 5}, 1, []int{0, 1, 2, 3, 4}},
+		{"All", []int{5, 4, 3, 2, 1, 0}, 6, []int{}},
+		{"None", nil, 0, []int{0, 1, 2, 3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slice := arena.NewVec[int](a, 0, 1, 2, 3, 4, 5)
+			input := slices.Clone(tt.indices)
+			if removed := slice.RemoveIndices(tt.indices); removed != tt.removed {
+				t.Errorf("Expected %d removed, got %d", tt.removed, removed)
+			}
+			if !slices.Equal(slice.Slice(), tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, slice.Slice())
+			}
+			if !slices.Equal(tt.indices, input) {
+				t.Errorf("Expected indices to be left unchanged, got %v", tt.indices)
+			}
+		})
+	}
+
+	// Repeated calls must not consume arena memory
+	slice := arena.NewVec[int](a, 0, 1, 2, 3, 4, 5)
+	used := a.Used()
+	for range 100 {
+		slice.RemoveIndices([]int{9, 7, 8})
+	}
+	if a.Used() != used {
+		t.Errorf("Expected no arena growth from RemoveIndices, Used %d -> %d", used, a.Used())
+	}
+}
+
+func TestVecSortedInsertUnique(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	set := arena.NewVec[int](a)
+	inputs := []int{50, 10, 30, 10, 70, 50, 20, 0, 70, 40}
+	for _, v := range inputs {
+		i, existed := set.BinarySearch(v, cmp.Compare[int])
+		idx, inserted := set.SortedInsertUnique(v, cmp.Compare[int])
+		if inserted == existed {
+			t.Errorf("SortedInsertUnique(%d): inserted=%v but BinarySearch found=%v", v, inserted, existed)
+		}
+		if idx != i {
+			t.Errorf("SortedInsertUnique(%d) index = %d, want %d", v, idx, i)
+		}
+		if got, _ := set.Get(idx); got != v {
+			t.Errorf("Expected %d at index %d, got %d", v, idx, got)
+		}
+	}
+
+	expected := []int{0, 10, 20, 30, 40, 50, 70}
+	if !slices.Equal(set.Slice(), expected) {
+		t.Errorf("Expected %v, got %v", expected, set.Slice())
+	}
+	for i, v := range expected {
+		if idx, found := set.BinarySearch(v, cmp.Compare[int]); !found || idx != i {
+			t.Errorf("BinarySearch(%d) = %d, %v; want %d, true", v, idx, found, i)
+		}
+	}
+	if idx, found := set.BinarySearch(60, cmp.Compare[int]); found || idx != 6 {
+		t.Errorf("BinarySearch(60) = %d, %v; want 6, false", idx, found)
+	}
+}
+
+func TestVecCompact(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	tests := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{"Interspersed", []int{0, 1, 0, 2, 3, 0}, []int{1, 2, 3}},
+		{"RemoveAll", []int{0, 0, 0}, []int{}},
+		{"RemoveNone", []int{4, 5, 6}, []int{4, 5, 6}},
+		{"Empty", nil, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slice := arena.NewVec[int](a, tt.input...)
+			if n := arena.VecCompactZero(slice); n != len(tt.expected) {
+				t.Errorf("Expected new length %d, got %d", len(tt.expected), n)
+			}
+			if slice.Len() != len(tt.expected) || !reflect.DeepEqual(append([]int{}, slice.Slice()...), tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, slice.Slice())
+			}
+			// The truncated tail is zeroed
+			if tail := slice.Slice()[slice.Len():len(tt.input)]; len(tail) > 0 {
+				for i, v := range tail {
+					if v != 0 {
+						t.Errorf("Expected zeroed tail, got %d at %d", v, i)
+					}
+				}
+			}
+		})
+	}
+
+	words := arena.NewVec[string](a, "keep", "drop", "keep", "drop")
+	n := words.CompactFunc(func(s string) bool { return s == "keep" })
+	if n != 2 || !reflect.DeepEqual(words.Slice(), []string{"keep", "keep"}) {
+		t.Errorf("CompactFunc: expected [keep keep], got %v", words.Slice())
+	}
+}
+
+func TestVecClearZero(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	type thing struct{ payload [64]byte }
+	slice := arena.NewVec[*thing](a, &thing{}, &thing{}, &thing{})
+	capacity := slice.Cap()
+
+	slice.ClearZero()
+	if slice.Len() != 0 || slice.Cap() != capacity {
+		t.Errorf("Expected len 0 cap %d, got len %d cap %d", capacity, slice.Len(), slice.Cap())
+	}
+	for i, p := range slice.Slice()[:3] {
+		if p != nil {
+			t.Errorf("Expected nil at spare index %d after ClearZero, got %p", i, p)
+		}
+	}
+
+	// Plain Clear leaves the old values in the spare capacity
+	kept := &thing{}
+	slice.Append(kept)
+	slice.Clear()
+	if p := slice.Slice()[:1][0]; p != kept {
+		t.Errorf("Expected Clear to keep the old value in spare capacity, got %p", p)
+	}
+}
+
+func TestVecGrowInPlace(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	slice := arena.NewVec[int64](a)
+	base := unsafe.Pointer(unsafe.SliceData(slice.Slice()[:1]))
+	for i := 0; i < 4000; i++ {
+		slice.AppendOne(int64(i))
+		if p := unsafe.Pointer(unsafe.SliceData(slice.Slice())); p != base {
+			t.Fatalf("Expected growth in place at len %d, backing moved from %p to %p", slice.Len(), base, p)
+		}
+	}
+	for i, v := range slice.Slice() {
+		if v != int64(i) {
+			t.Fatalf("Expected %d at %d, got %d", i, i, v)
+		}
+	}
+
+	// No orphaned blocks: the next allocation starts right after the Vec's capacity
+	next := arena.Alloc[int64](a)
+	if want := uintptr(base) + uintptr(slice.Cap())*8; uintptr(unsafe.Pointer(next)) != want {
+		t.Errorf("Expected next allocation at %#x, got %p", want, next)
+	}
+
+	// Once another allocation follows, growth falls back to copying
+	other := arena.NewVec[int64](a, 1, 2, 3)
+	_ = arena.Alloc[int64](a)
+	before := unsafe.Pointer(unsafe.SliceData(other.Slice()))
+	for i := 0; i < 100; i++ {
+		other.AppendOne(int64(i))
+	}
+	if unsafe.Pointer(unsafe.SliceData(other.Slice())) == before {
+		t.Error("Expected the backing to move when it is not the most recent allocation")
+	}
+	if v, _ := other.Get(2); v != 3 {
+		t.Errorf("Expected 3 at index 2 after copy, got %d", v)
+	}
+}
+
+func TestVecSubSlice(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	v := arena.NewVec[int](a)
+	for i := range 10 {
+		v.Append(i)
+	}
+
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   []int
+		ok     bool
+	}{
+		{"full range", 0, 10, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, true},
+		{"window", 3, 6, []int{3, 4, 5}, true},
+		{"empty at end", 10, 10, []int{}, true},
+		{"inverted", 6, 3, nil, false},
+		{"negative lo", -1, 3, nil, false},
+		{"hi beyond len", 5, 11, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := v.SubSlice(tt.lo, tt.hi)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Zero-copy, with capacity capped so appends cannot clobber the Vec
+	window, _ := v.SubSlice(2, 4)
+	window[0] = 20
+	if x, _ := v.Get(2); x != 20 {
+		t.Errorf("Expected SubSlice to share memory with the Vec, got %d", x)
+	}
+	if cap(window) != 2 {
+		t.Errorf("Expected capacity 2, got %d", cap(window))
+	}
+	_ = append(window, 99)
+	if x, _ := v.Get(4); x != 4 {
+		t.Errorf("Expected append to the window to leave element 4 intact, got %d", x)
+	}
+}
+
+func TestVecShuffleSample(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	newVec := func() *arena.Vec[int] {
+		v := arena.NewVec[int](a)
+		for i := range 20 {
+			v.Append(i)
+		}
+		return v
+	}
+
+	// Same seed, same permutation
+	v1, v2 := newVec(), newVec()
+	v1.Shuffle(rand.New(rand.NewSource(42)))
+	v2.Shuffle(rand.New(rand.NewSource(42)))
+	if !slices.Equal(v1.Slice(), v2.Slice()) {
+		t.Errorf("Expected deterministic permutation, got %v and %v", v1.Slice(), v2.Slice())
+	}
+	if slices.Equal(v1.Slice(), newVec().Slice()) {
+		t.Errorf("Expected shuffle to change the order")
+	}
+	sorted := slices.Clone(v1.Slice())
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, newVec().Slice()) {
+		t.Errorf("Expected a permutation of 0..19, got %v", v1.Slice())
+	}
+
+	tests := []struct {
+		name string
+		k    int
+		want int
+	}{
+		{"subset", 5, 5},
+		{"all", 20, 20},
+		{"more than len", 50, 20},
+		{"zero", 0, 0},
+		{"negative", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newVec()
+			got := v.Sample(rand.New(rand.NewSource(7)), tt.k)
+			if len(got) != tt.want {
+				t.Fatalf("Expected %d elements, got %d", tt.want, len(got))
+			}
+			seen := make(map[int]bool)
+			for _, x := range got {
+				if x < 0 || x >= 20 || seen[x] {
+					t.Errorf("Expected distinct elements from the Vec, got %v", got)
+				}
+				seen[x] = true
+			}
+			if len(got) > 0 && !a.Owns(unsafe.Pointer(&got[0])) {
+				t.Errorf("Expected sample to be allocated in the arena")
+			}
+			again := v.Sample(rand.New(rand.NewSource(7)), tt.k)
+			if !slices.Equal(got, again) {
+				t.Errorf("Expected deterministic sample, got %v and %v", got, again)
+			}
+		})
+	}
+}
+
+func TestVecPrepend(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	v := arena.NewVec(a, 3, 4)
+	v.Prepend(2)
+	v.Prepend(1)
+	if !slices.Equal(v.Slice(), []int{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4] after Prepend, got %v", v.Slice())
+	}
+
+	v.PrependSlice([]int{-1, 0})
+	if !slices.Equal(v.Slice(), []int{-1, 0, 1, 2, 3, 4}) {
+		t.Errorf("Expected [-1 0 1 2 3 4] after PrependSlice, got %v", v.Slice())
+	}
+	v.PrependSlice(nil)
+	if v.Len() != 6 {
+		t.Errorf("Expected PrependSlice(nil) to be a no-op, got Len %d", v.Len())
+	}
+
+	// Prepending the Vec's own contents
+	self := arena.NewVec(a, 1, 2, 3)
+	self.PrependSlice(self.Slice())
+	if !slices.Equal(self.Slice(), []int{1, 2, 3, 1, 2, 3}) {
+		t.Errorf("Expected [1 2 3 1 2 3] after self-prepend, got %v", self.Slice())
+	}
+
+	// Prepend into an empty Vec and across growth
+	e := arena.NewVec[int](a)
+	for i := range 100 {
+		e.Prepend(i)
+	}
+	for i, x := range e.Slice() {
+		if x != 99-i {
+			t.Fatalf("Expected %d at index %d, got %d", 99-i, i, x)
+		}
+	}
+
+	// Insert at Len appends; beyond Len fails
+	ins := arena.NewVec(a, 1, 2)
+	if !ins.Insert(2, 3) || !slices.Equal(ins.Slice(), []int{1, 2, 3}) {
+		t.Errorf("Expected Insert at Len to append, got %v", ins.Slice())
+	}
+	if ins.Insert(4, 5) {
+		t.Errorf("Expected Insert beyond Len to fail")
+	}
+	if !ins.Insert(1, 9) || !slices.Equal(ins.Slice(), []int{1, 9, 2, 3}) {
+		t.Errorf("Expected Insert in the middle to shift, got %v", ins.Slice())
+	}
+}
+
+func TestVecClonePooled(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	var pool sync.Pool
+	v := arena.NewVec(a, 1, 2, 3)
+
+	snap := v.ClonePooled(&pool)
+	if !slices.Equal(snap, []int{1, 2, 3}) {
+		t.Fatalf("Expected [1 2 3], got %v", snap)
+	}
+	if a.Owns(unsafe.Pointer(&snap[0])) {
+		t.Errorf("Expected the clone to live on the heap, not in the arena")
+	}
+	snap[0] = 100
+	if x, _ := v.Get(0); x != 1 {
+		t.Errorf("Expected the clone to be independent of the Vec, got %d", x)
+	}
+
+	// A larger pooled slice is trimmed to the Vec's length (sync.Pool may drop
+	// items, so reuse itself is measured by BenchmarkVecClone rather than asserted)
+	big := []int{9, 9, 9, 9, 9, 9, 9, 9}
+	pool.Put(&big)
+	if reused := v.ClonePooled(&pool); !slices.Equal(reused, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", reused)
+	}
+
+	// A too-small pooled slice is replaced
+	small := make([]int, 1)
+	pool.Put(&small)
+	v.Append(4, 5)
+	grown := v.ClonePooled(&pool)
+	if !slices.Equal(grown, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected [1 2 3 4 5], got %v", grown)
+	}
+
+	// Foreign values in the pool are ignored
+	pool.Put("not a slice")
+	if got := v.ClonePooled(&pool); len(got) != 5 {
+		t.Errorf("Expected 5 elements, got %v", got)
+	}
+}
+
+func TestVecTransform(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	slice := arena.NewVec[int](a, -5, 3, 12, 7, 100)
+	length, capacity := slice.Len(), slice.Cap()
+
+	slice.Transform(func(v int) int { return min(max(v, 0), 10) })
+	if !slices.Equal(slice.Slice(), []int{0, 3, 10, 7, 10}) {
+		t.Errorf("Expected clamped [0 3 10 7 10], got %v", slice.Slice())
+	}
+	if slice.Len() != length || slice.Cap() != capacity {
+		t.Errorf("Expected len %d cap %d, got len %d cap %d", length, capacity, slice.Len(), slice.Cap())
+	}
+
+	words := arena.NewVec[string](a, "Hello", "WORLD")
+	words.Transform(strings.ToLower)
+	if !slices.Equal(words.Slice(), []string{"hello", "world"}) {
+		t.Errorf("Expected [hello world], got %v", words.Slice())
+	}
+
+	if n := testing.AllocsPerRun(100, func() { slice.Transform(func(v int) int { return v * 2 }) }); n != 0 {
+		t.Errorf("Expected 0 allocs, got %v", n)
+	}
+}
+
+func TestVecEach(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	slice := arena.NewVec[int](a, 1, 2, 3, 4)
+
+	sum := 0
+	slice.Each(func(v int) { sum += v })
+	if sum != 10 {
+		t.Errorf("Each: expected sum 10, got %d", sum)
+	}
+
+	var indices []int
+	slice.EachIndexed(func(i int, v int) {
+		if v != i+1 {
+			t.Errorf("EachIndexed: expected value %d at %d, got %d", i+1, i, v)
+		}
+		indices = append(indices, i)
+	})
+	if !reflect.DeepEqual(indices, []int{0, 1, 2, 3}) {
+		t.Errorf("EachIndexed: expected indices [0 1 2 3], got %v", indices)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { slice.Each(func(v int) { sum += v }) }); n != 0 {
+		t.Errorf("Each: expected 0 allocs, got %v", n)
+	}
+}
+
+func BenchmarkVecEach(b *testing.B) {
+	a := arena.New(1024*1024, arena.BUMP)
+	defer a.Delete()
+
+	slice := arena.NewVec[int](a)
+	for i := 0; i < 1000; i++ {
+		slice.Append(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		slice.Each(func(v int) { sum += v })
+	}
+}
+
+func TestVecHeap(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	less := func(x, y int) bool { return x < y }
+	tests := []struct {
+		name  string
+		input []int
+	}{
+		{"empty", nil},
+		{"single", []int{7}},
+		{"ascending", []int{1, 2, 3, 4, 5}},
+		{"descending", []int{5, 4, 3, 2, 1}},
+		{"duplicates", []int{3, 1, 3, 1, 2, 2}},
+		{"random", rand.New(rand.NewSource(1)).Perm(200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := arena.NewVec[int](a)
+			for _, v := range tt.input {
+				pq.PushHeap(v, less)
+			}
+			var got []int
+			for {
+				v, ok := pq.PopHeap(less)
+				if !ok {
+					break
+				}
+				got = append(got, v)
+			}
+			want := slices.Clone(tt.input)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("Expected priority order %v, got %v", want, got)
+			}
+			if pq.Len() != 0 {
+				t.Errorf("Expected empty Vec after popping all, got len %d", pq.Len())
+			}
+		})
+	}
+
+	// Interleaved pushes and pops, max-heap of tasks by priority
+	type task struct {
+		name     string
+		priority int
+	}
+	higher := func(x, y task) bool { return x.priority > y.priority }
+	pq := arena.NewVec[task](a)
+	pq.PushHeap(task{"low", 1}, higher)
+	pq.PushHeap(task{"high", 9}, higher)
+	if top, _ := pq.PopHeap(higher); top.name != "high" {
+		t.Errorf("Expected high first, got %s", top.name)
+	}
+	pq.PushHeap(task{"mid", 5}, higher)
+	pq.PushHeap(task{"urgent", 10}, higher)
+	for _, want := range []string{"urgent", "mid", "low"} {
+		if top, ok := pq.PopHeap(higher); !ok || top.name != want {
+			t.Errorf("Expected %s, got %s (ok=%v)", want, top.name, ok)
+		}
+	}
+	if _, ok := pq.PopHeap(higher); ok {
+		t.Errorf("Expected PopHeap on empty Vec to return false")
+	}
+}
+
+func TestVecZip(t *testing.T) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	tests := []struct {
+		name   string
+		keys   []string
+		values []int
+		want   int
+	}{
+		{"equal length", []string{"a", "b", "c"}, []int{1, 2, 3}, 3},
+		{"keys longer", []string{"a", "b", "c", "d"}, []int{1, 2}, 2},
+		{"values longer", []string{"a"}, []int{1, 2, 3}, 1},
+		{"empty", nil, []int{1}, 0},
+		{"large", strings.Split(strings.Repeat("x,", 99)+"x", ","), make([]int, 150), 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xs, ys := arena.NewVec(a, tt.keys...), arena.NewVec(a, tt.values...)
+			zipped := arena.VecZip(a, xs, ys)
+			if zipped.Len() != tt.want {
+				t.Fatalf("Expected %d pairs, got %d", tt.want, zipped.Len())
+			}
+			for i, p := range zipped.Slice() {
+				if p.Key != tt.keys[i] || p.Value != tt.values[i] {
+					t.Errorf("Expected pair %d = (%s, %d), got (%s, %d)", i, tt.keys[i], tt.values[i], p.Key, p.Value)
+				}
+			}
+
+			// Round trip: unzipping gives back the (truncated) inputs
+			keys, values := arena.VecUnzip(a, zipped)
+			if !slices.Equal(keys.Slice(), tt.keys[:tt.want]) || !slices.Equal(values.Slice(), tt.values[:tt.want]) {
+				t.Errorf("Expected unzip to return %v and %v, got %v and %v", tt.keys[:tt.want], tt.values[:tt.want], keys.Slice(), values.Slice())
+			}
+			if tt.want > 0 && (!arena.OwnsSlice(a, zipped.Slice()) || !arena.OwnsSlice(a, keys.Slice())) {
+				t.Errorf("Expected results to be allocated in the arena")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package arena_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thebagchi/arena-go"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	w := arena.NewSlidingWindow(a, 10*time.Second, time.Second)
+	w.Add(at(0), 1)
+	w.Add(at(500*time.Millisecond), 2)
+	w.Add(at(3*time.Second), 4)
+	w.Add(at(9*time.Second+999*time.Millisecond), 8)
+
+	tests := []struct {
+		name string
+		now  time.Duration
+		want int64
+	}{
+		{"same bucket", 0, 3},
+		{"later bucket", 3 * time.Second, 7},
+		{"end of window", 9*time.Second + 999*time.Millisecond, 15},
+		{"first bucket expired", 10 * time.Second, 12},
+		{"second event bucket expired", 13 * time.Second, 8},
+		{"last bucket still live", 18 * time.Second, 8},
+		{"last bucket expired", 19 * time.Second, 0},
+		{"all expired", 20 * time.Second, 0},
+		{"far future", time.Hour, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.Count(at(tt.now)); got != tt.want {
+				t.Errorf("Expected count %d at +%v, got %d", tt.want, tt.now, got)
+			}
+		})
+	}
+
+	t.Run("ring reuse", func(t *testing.T) {
+		w := arena.NewSlidingWindow(a, 3*time.Second, time.Second)
+		// Several laps around the 3-bucket ring; each bucket holds its second's index
+		for s := range 10 {
+			w.Add(at(time.Duration(s)*time.Second), int64(s))
+			want := int64(s)
+			if s >= 1 {
+				want += int64(s - 1)
+			}
+			if s >= 2 {
+				want += int64(s - 2)
+			}
+			if got := w.Count(at(time.Duration(s) * time.Second)); got != want {
+				t.Errorf("Expected count %d at second %d, got %d", want, s, got)
+			}
+		}
+		// An event older than the window is dropped
+		w.Add(at(time.Second), 100)
+		if got := w.Count(at(9 * time.Second)); got != 24 {
+			t.Errorf("Expected stale event to be ignored, got %d", got)
+		}
+		w.Reset()
+		if got := w.Count(at(9 * time.Second)); got != 0 {
+			t.Errorf("Expected 0 after Reset, got %d", got)
+		}
+	})
+
+	t.Run("window rounds up to whole buckets", func(t *testing.T) {
+		w := arena.NewSlidingWindow(a, 2500*time.Millisecond, time.Second)
+		w.Add(at(0), 1)
+		if got := w.Count(at(2 * time.Second)); got != 1 {
+			t.Errorf("Expected 3-bucket window to keep the event, got %d", got)
+		}
+		if got := w.Count(at(3 * time.Second)); got != 0 {
+			t.Errorf("Expected event to expire after 3 buckets, got %d", got)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		w := arena.NewSlidingWindow(a, time.Minute, time.Second)
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 1000 {
+					w.Add(at(time.Duration(i%30)*time.Second), 1)
+				}
+			}()
+		}
+		wg.Wait()
+		if got := w.Count(at(30 * time.Second)); got != 8000 {
+			t.Errorf("Expected 8000 events, got %d", got)
+		}
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic for a bucket longer than the window")
+		}
+	}()
+	arena.NewSlidingWindow(a, time.Second, time.Minute)
+}
@@ -1,6 +1,12 @@
 package arena_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/thebagchi/arena-go"
@@ -80,3 +86,350 @@ func TestWriter(t *testing.T) {
 		t.Errorf("Write large data: expected bytes len 1000, got %d", len(w.Bytes()))
 	}
 }
+
+func TestWriterTruncateFraming(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	w := arena.NewWriter(a)
+
+	writeFrame := func(payload string, fail bool) {
+		mark := w.Offset()
+		w.WriteByte(0) // length placeholder
+		w.WriteString(payload)
+		if fail {
+			// Simulated encoding error: discard the partial frame
+			w.Truncate(mark)
+			return
+		}
+		end := w.Offset()
+		w.SeekTo(mark)
+		w.WriteByte(byte(len(payload)))
+		w.SeekTo(end)
+	}
+
+	writeFrame("broken", true)
+	writeFrame("good", false)
+
+	expected := "\x04good"
+	if string(w.Bytes()) != expected {
+		t.Errorf("Framing: expected %q, got %q", expected, string(w.Bytes()))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Truncate beyond Len should panic")
+		}
+	}()
+	w.Truncate(w.Len() + 1)
+}
+
+func TestWriterSeekBounds(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	w := arena.NewWriterSize(a, 64)
+
+	seekPanics := func(n int) (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		w.SeekTo(n)
+		return false
+	}
+
+	w.WriteString("abcdef")
+	w.SeekTo(0)
+	w.WriteByte('X')
+	if seekPanics(6) {
+		t.Errorf("Expected SeekTo back to written data to succeed")
+	}
+	if string(w.Bytes()) != "Xbcdef" {
+		t.Errorf("Expected %q, got %q", "Xbcdef", string(w.Bytes()))
+	}
+	if !seekPanics(7) {
+		t.Errorf("Expected SeekTo past the written data to panic")
+	}
+
+	// Truncated bytes can no longer be reached
+	w.Truncate(2)
+	if !seekPanics(4) {
+		t.Errorf("Expected SeekTo past a Truncate to panic")
+	}
+	w.Reset()
+	if !seekPanics(1) {
+		t.Errorf("Expected SeekTo past a Reset to panic")
+	}
+}
+
+func TestNewWriterSize(t *testing.T) {
+	a := arena.New(16, arena.BUMP)
+	defer a.Delete()
+
+	w := arena.NewWriterSize(a, 8192)
+	if w.Cap() < 8192 || w.Len() != 0 {
+		t.Fatalf("Expected empty writer with cap >= 8192, got len %d cap %d", w.Len(), w.Cap())
+	}
+	w.Write(make([]byte, 8192))
+	if w.Cap() != 8192 {
+		t.Errorf("Expected no grow within the requested capacity, cap now %d", w.Cap())
+	}
+	if w := arena.NewWriterSize(a, 0); w.Cap() != arena.NewWriter(a).Cap() {
+		t.Errorf("Expected default capacity for size 0, got %d", w.Cap())
+	}
+}
+
+func TestWriterPool(t *testing.T) {
+	a := arena.New(64, arena.BUMP)
+	defer a.Delete()
+
+	pool := arena.NewWriterPool(a, 256)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				w := pool.Get()
+				if w.Len() != 0 {
+					t.Errorf("Expected an empty writer from Get, got len %d", w.Len())
+				}
+				msg := fmt.Sprintf("goroutine %d message %d", g, i)
+				w.WriteString(msg)
+				if string(w.Bytes()) != msg {
+					t.Errorf("Expected %q, got %q", msg, w.Bytes())
+				}
+				pool.Put(w)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Writers pooled before a Reset are not handed out again
+	w := pool.Get()
+	w.WriteString("before reset")
+	pool.Put(w)
+	a.Reset()
+	fresh := arena.MakeSlice[byte](a, 256, 256)
+	for i := range fresh {
+		fresh[i] = 0xAA
+	}
+	w = pool.Get()
+	w.WriteString("after reset")
+	for i, b := range fresh {
+		if b != 0xAA {
+			t.Fatalf("Expected pooled writer to leave fresh allocation alone, byte %d is 0x%X", i, b)
+		}
+	}
+
+	readers := arena.NewReaderPool(a)
+	r := readers.Get([]byte("abc"))
+	if b, _ := io.ReadAll(r); string(b) != "abc" {
+		t.Errorf("Expected abc, got %q", b)
+	}
+	readers.Put(r)
+	r = readers.Get([]byte("xyz"))
+	if r.Pos() != 0 || r.Len() != 3 {
+		t.Errorf("Expected pooled reader at position 0 with 3 bytes, got pos %d len %d", r.Pos(), r.Len())
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	tests := []struct {
+		name   string
+		chunks []string
+		want   string
+	}{
+		{"single write", []string{"one\ntwo\nthree\n"}, "> one\n> two\n> three\n"},
+		{"no trailing newline", []string{"one\ntwo"}, "> one\n> two"},
+		{"line split across writes", []string{"on", "e\ntw", "o\n"}, "> one\n> two\n"},
+		{"newline at write boundary", []string{"one\n", "two\n"}, "> one\n> two\n"},
+		{"empty lines", []string{"a\n\nb\n"}, "> a\n> \n> b\n"},
+		{"byte at a time", strings.Split("x\ny\n", ""), "> x\n> y\n"},
+		{"empty writes", []string{"", "a", "", "\n", ""}, "> a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := arena.NewBuffer(a)
+			pw := arena.NewPrefixWriter(buf, "> ")
+			for _, c := range tt.chunks {
+				n, err := pw.Write([]byte(c))
+				if err != nil || n != len(c) {
+					t.Fatalf("Write(%q) = %d, %v; expected %d, nil", c, n, err, len(c))
+				}
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	// Composes with fmt and with an arena Writer as the destination
+	w := arena.NewWriter(a)
+	pw := arena.NewPrefixWriter(w, "[req-42] ")
+	fmt.Fprintf(pw, "started\nuser=%s\n", "alice")
+	fmt.Fprint(pw, "done\n")
+	want := "[req-42] started\n[req-42] user=alice\n[req-42] done\n"
+	if got := string(w.Bytes()); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if strings.Count(string(w.Bytes()), "[req-42]") != 3 {
+		t.Errorf("Expected the prefix exactly once per line")
+	}
+}
+
+// recordingWriter records each Write it receives and can fail after a number of calls
+type recordingWriter struct {
+	bytes.Buffer
+	writes []int
+	failAt int // fail the failAt-th write (1-based); 0 never fails
+}
+
+func (r *recordingWriter) Write(p []byte) (int, error) {
+	r.writes = append(r.writes, len(p))
+	if r.failAt > 0 && len(r.writes) >= r.failAt {
+		return 0, errors.New("connection reset")
+	}
+	return r.Buffer.Write(p)
+}
+
+func TestFlushWriter(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+
+	const threshold = 256
+	dst := &recordingWriter{}
+	w := arena.NewWriterSize(a, threshold)
+	fw := arena.NewFlushWriter(w, dst, threshold)
+
+	var want strings.Builder
+	for i := range 500 {
+		line := fmt.Sprintf("row %d: %s\n", i, strings.Repeat("x", i%40))
+		want.WriteString(line)
+		if _, err := fmt.Fprint(fw, line); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		if fw.Buffered() >= threshold {
+			t.Fatalf("Expected buffered bytes below threshold, got %d", fw.Buffered())
+		}
+	}
+	used := a.Used()
+
+	// A write larger than the threshold goes straight through
+	big := strings.Repeat("B", 3*threshold)
+	want.WriteString(big)
+	if n, err := fw.Write([]byte(big)); err != nil || n != len(big) {
+		t.Fatalf("Large write = %d, %v; expected %d, nil", n, err, len(big))
+	}
+	if err := fw.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if fw.Buffered() != 0 {
+		t.Errorf("Expected empty buffer after Flush, got %d", fw.Buffered())
+	}
+
+	if dst.String() != want.String() {
+		t.Errorf("Destination content mismatch: got %d bytes, want %d", dst.Len(), want.Len())
+	}
+	if len(dst.writes) < 10 {
+		t.Errorf("Expected many flushes, got %d", len(dst.writes))
+	}
+	for i, n := range dst.writes[:len(dst.writes)-1] {
+		if n > threshold && n != len(big) {
+			t.Errorf("Flush %d wrote %d bytes, above the threshold", i, n)
+		}
+	}
+	if w.Cap() != threshold || a.Used() != used {
+		t.Errorf("Expected the arena buffer to stay bounded, got Cap %d and Used %d -> %d", w.Cap(), used, a.Used())
+	}
+
+	// Destination errors are sticky
+	failing := &recordingWriter{failAt: 1}
+	fw = arena.NewFlushWriter(arena.NewWriterSize(a, 16), failing, 16)
+	fw.Write([]byte("0123456789"))
+	if _, err := fw.Write([]byte("0123456789")); err == nil {
+		t.Fatalf("Expected flush error to surface from Write")
+	}
+	if _, err := fw.Write([]byte("x")); err == nil {
+		t.Errorf("Expected Write to keep returning the destination error")
+	}
+	if err := fw.Flush(); err == nil {
+		t.Errorf("Expected Flush to keep returning the destination error")
+	}
+}
+
+func TestWriterArena(t *testing.T) {
+	a := arena.New(1, arena.BUMP)
+	defer a.Delete()
+	other := arena.New(1, arena.BUMP)
+	defer other.Delete()
+
+	w := arena.NewWriter(a)
+	w.WriteString("header")
+	if w.Arena() != a {
+		t.Fatalf("Expected Writer.Arena to return the backing arena")
+	}
+
+	scratch := arena.MakeSlice[int](w.Arena(), 16, 16)
+	if !arena.OwnsSlice(a, scratch) {
+		t.Errorf("Expected slice allocated via Writer.Arena to be owned by the arena")
+	}
+	if arena.OwnsSlice(other, scratch) {
+		t.Errorf("Expected slice not to be owned by an unrelated arena")
+	}
+
+	if b := arena.NewBuffer(a); b.Arena() != a {
+		t.Errorf("Expected Buffer.Arena to return the backing arena")
+	}
+	if r := arena.NewReader(a, w.Bytes()); r.Arena() != a {
+		t.Errorf("Expected Reader.Arena to return the backing arena")
+	}
+}
+
+func benchmarkWriterPayload(b *testing.B, newWriter func(a *arena.Arena) *arena.Writer) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	chunk := make([]byte, 1024)
+	grows := 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := newWriter(a)
+		capacity := w.Cap()
+		for j := 0; j < 64; j++ {
+			w.Write(chunk)
+			if w.Cap() != capacity {
+				capacity = w.Cap()
+				grows++
+			}
+		}
+		a.Reset()
+	}
+	b.ReportMetric(float64(grows)/float64(b.N), "grows/op")
+}
+
+func BenchmarkWriterDefault(b *testing.B) {
+	benchmarkWriterPayload(b, arena.NewWriter)
+}
+
+func BenchmarkWriterSized(b *testing.B) {
+	benchmarkWriterPayload(b, func(a *arena.Arena) *arena.Writer { return arena.NewWriterSize(a, 64*1024) })
+}
+
+func BenchmarkWriterPool(b *testing.B) {
+	a := arena.New(1024, arena.BUMP)
+	defer a.Delete()
+
+	pool := arena.NewWriterPool(a, 4096)
+	payload := make([]byte, 512)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			w := pool.Get()
+			w.Write(payload)
+			pool.Put(w)
+		}
+	})
+}
@@ -79,3 +79,43 @@ func OwnsString(a *Arena, s string) bool {
 	}
 	return a.Allocator.Owns(unsafe.Pointer(unsafe.StringData(s)))
 }
+
+// Sized is implemented by every container in the package (Vec, Map, FrozenMap,
+// SkipList, BTree, MultiMap, IntMap, SymbolTable, Buffer, Writer, Reader), so generic
+// code can accept any of them.
+type Sized interface {
+	Len() int
+}
+
+// Capacitied is implemented by the contiguous containers (Vec, Buffer, Writer) whose
+// backing storage has a capacity beyond their length.
+type Capacitied interface {
+	Sized
+	Cap() int
+}
+
+// Compile-time checks that the containers satisfy the interfaces
+var (
+	_ Sized      = (*Map[int, int])(nil)
+	_ Sized      = (*FrozenMap[int, int])(nil)
+	_ Sized      = (*SkipList[int, int])(nil)
+	_ Sized      = (*BTree[int, int])(nil)
+	_ Sized      = (*MultiMap[int, int])(nil)
+	_ Sized      = (*IntMap[int])(nil)
+	_ Sized      = (*SymbolTable)(nil)
+	_ Sized      = (*Reader)(nil)
+	_ Capacitied = (*Vec[int])(nil)
+	_ Capacitied = (*Buffer)(nil)
+	_ Capacitied = (*Writer)(nil)
+)
+
+// IsEmpty reports whether the container c holds no elements.
+//
+// Example:
+//
+//	if arena.IsEmpty(vec) || arena.IsEmpty(index) {
+//	    return
+//	}
+func IsEmpty[T Sized](c T) bool {
+	return c.Len() == 0
+}
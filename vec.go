@@ -12,7 +12,11 @@ package arena
 
 import (
 	"iter"
+	"math/rand"
+	"slices"
 	"sort"
+	"sync"
+	"unsafe"
 )
 
 // Vec[T] – the ultimate appendable slice in arena memory
@@ -22,8 +26,8 @@ import (
 //
 // Core operations: AppendOne, Push, Pop, Get, Set, Insert, Remove
 // Bulk operations: AppendSlice, Append, Resize, Clear, Reset
-// Algorithms: Sort, SortStable, SortBy, Reverse, Contains, IndexOf
-// Conversion: Clone (heap), CloneSlice (arena), ToSlice
+// Algorithms: Sort, SortStable, SortBy, Reverse, Contains, IndexOf, BinarySearch
+// Conversion: Clone (heap), CloneSlice (arena), CloneTo (caller buffer), ToSlice
 // Iteration: All, All2, Keys, Iter (pull-based), range loops
 //
 // Usage:
@@ -84,13 +88,30 @@ func (s *Vec[T]) Cap() int {
 
 // Slice returns the current slice (zero-copy)
 // This provides access to the underlying data as a standard Go slice.
-// The returned slice shares memory with the ArenaSlice and remains valid
+// The returned slice shares memory with the Vec and remains valid
 // until the arena is deleted or reset.
 // ⚠️ CAUTION: Storing the returned slice in a long-lived variable may cause heap escape.
 func (s *Vec[T]) Slice() []T {
 	return s.data
 }
 
+// SubSlice returns the elements in [lo, hi) as a zero-copy subslice, or false if
+// the range is invalid (negative, inverted, or beyond Len).
+// The result's capacity is capped at hi, so appending to it never overwrites the
+// Vec's later elements or spare capacity. It is valid until the next mutation of the Vec.
+//
+// Example:
+//
+//	if window, ok := vec.SubSlice(10, 20); ok {
+//	    process(window)
+//	}
+func (s *Vec[T]) SubSlice(lo, hi int) ([]T, bool) {
+	if lo < 0 || hi < lo || hi > len(s.data) {
+		return nil, false
+	}
+	return s.data[lo:hi:hi], true
+}
+
 // AppendOne appends one element
 // This operation never allocates on the heap - all data is stored in arena memory.
 // Small slices (up to ssoThreshold elements) get small initial capacity.
@@ -108,7 +129,7 @@ func (s *Vec[T]) AppendOne(v T) {
 }
 
 // Append adds multiple elements to the slice
-// Similar to Go's built-in append function but for ArenaSlice.
+// Similar to Go's built-in append function but for Vec.
 // This method takes any number of elements and appends them efficiently.
 //
 // Example:
@@ -161,6 +182,16 @@ func (s *Vec[T]) ensure(needed int) {
 		capacity = max(cap(s.data)*2, needed)
 	}
 
+	// Grow in place when the backing is the arena's most recent allocation,
+	// avoiding the copy and the orphaned block
+	if size := uint64(unsafe.Sizeof(*new(T))); size > 0 && cap(s.data) > 0 {
+		ptr := unsafe.Pointer(unsafe.SliceData(s.data))
+		if s.arena.extend(ptr, uint64(cap(s.data))*size, uint64(capacity)*size) {
+			s.data = unsafe.Slice((*T)(ptr), capacity)[:len(s.data)]
+			return
+		}
+	}
+
 	// Use MakeSlice from object.go to allocate from arena
 	temp := MakeSlice[T](s.arena, len(s.data), capacity)
 	copy(temp, s.data)
@@ -208,6 +239,44 @@ func (s *Vec[T]) Clone() []T {
 	return result
 }
 
+// ClonePooled is Clone with the heap slice taken from pool, for high-frequency
+// snapshot loops where Clone's make per call churns the GC. The pool must hold *[]T
+// values; an empty or too-small pooled slice is replaced by a new one. The caller
+// owns the result and should hand it back once done with it:
+//
+//	snap := vec.ClonePooled(&pool)
+//	publish(snap)
+//	pool.Put(&snap)
+func (s *Vec[T]) ClonePooled(pool *sync.Pool) []T {
+	var buf []T
+	if p, ok := pool.Get().(*[]T); ok && p != nil {
+		buf = *p
+	}
+	if cap(buf) < len(s.data) {
+		buf = make([]T, len(s.data))
+	}
+	buf = buf[:len(s.data)]
+	copy(buf, s.data)
+	return buf
+}
+
+// CloneTo copies the elements into dst, reusing its backing array when it has enough
+// capacity and growing it with append otherwise, and returns the result.
+// Keeping one dst across iterations makes repeated clones allocation-free once it
+// has grown to the largest Vec. Like Clone, dst is typically heap memory, so the
+// result outlives the arena.
+//
+// Example:
+//
+// var buf []int
+// for _, v := range vecs {
+// buf = v.CloneTo(buf)
+// process(buf)
+// }
+func (s *Vec[T]) CloneTo(dst []T) []T {
+	return append(dst[:0], s.data...)
+}
+
 // NewSlice creates a new Slice from initial data
 // All data is allocated from arena memory. Small slices benefit from SSO threshold.
 //
@@ -234,6 +303,72 @@ func NewVec[T any](a *Arena, initial ...T) *Vec[T] {
 	return as
 }
 
+// VecFromSeq collects every element of an iter.Seq into a new arena-backed Vec.
+// The element count is unknown up front, so the Vec grows as elements arrive.
+//
+// Example:
+//
+// evens := VecFromSeq(a, func(yield func(int) bool) {
+// for i := 0; i < 10; i += 2 {
+// if !yield(i) {
+// return
+// }
+// }
+// })
+func VecFromSeq[T any](a *Arena, seq iter.Seq[T]) *Vec[T] {
+	vec := NewVec[T](a)
+	for v := range seq {
+		vec.AppendOne(v)
+	}
+	return vec
+}
+
+// VecFromSeq2 collects every pair of an iter.Seq2 into a new arena-backed Vec of Pairs.
+//
+// Example:
+//
+// pairs := VecFromSeq2(a, m.All()) // *Vec[Pair[string, int]]
+func VecFromSeq2[K, V any](a *Arena, seq iter.Seq2[K, V]) *Vec[Pair[K, V]] {
+	vec := NewVec[Pair[K, V]](a)
+	for k, v := range seq {
+		vec.AppendOne(Pair[K, V]{Key: k, Value: v})
+	}
+	return vec
+}
+
+// VecZip pairs xs[i] with ys[i] into a new Vec allocated in a. The result has the
+// length of the shorter input; extra elements of the longer one are ignored.
+//
+// Example:
+//
+// rows := VecZip(a, names, scores) // *Vec[Pair[string, int]]
+func VecZip[A, B any](a *Arena, xs *Vec[A], ys *Vec[B]) *Vec[Pair[A, B]] {
+	n := min(len(xs.data), len(ys.data))
+	vec := &Vec[Pair[A, B]]{arena: a}
+	vec.ensure(n)
+	for i := range n {
+		vec.AppendOne(Pair[A, B]{Key: xs.data[i], Value: ys.data[i]})
+	}
+	return vec
+}
+
+// VecUnzip splits a Vec of pairs into a Vec of keys and a Vec of values, both allocated
+// in a. It is the inverse of VecZip.
+//
+// Example:
+//
+// names, scores := VecUnzip(a, rows)
+func VecUnzip[A, B any](a *Arena, zs *Vec[Pair[A, B]]) (*Vec[A], *Vec[B]) {
+	xs, ys := &Vec[A]{arena: a}, &Vec[B]{arena: a}
+	xs.ensure(len(zs.data))
+	ys.ensure(len(zs.data))
+	for _, z := range zs.data {
+		xs.AppendOne(z.Key)
+		ys.AppendOne(z.Value)
+	}
+	return xs, ys
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Extended Methods — Super User-Friendly!
 // ─────────────────────────────────────────────────────────────────────────────
@@ -254,6 +389,97 @@ func (s *Vec[T]) Pop() (T, bool) {
 	return val, true
 }
 
+// PushN appends several elements at once (symmetric with PopN)
+func (s *Vec[T]) PushN(elems ...T) {
+	s.AppendSlice(elems)
+}
+
+// PopN removes the last n elements and returns them as a zero-copy view.
+// Returns (nil, false) if n is negative or greater than Len.
+// ⚠️ CAUTION: The returned slice aliases the Vec's backing array and is only
+// valid until the next mutation (Push, Append, Insert, ...) overwrites it.
+//
+// Example:
+//
+// stack := NewVec[int](a, 1, 2, 3, 4)
+// top, _ := stack.PopN(2) // [3 4], stack is now [1 2]
+func (s *Vec[T]) PopN(n int) ([]T, bool) {
+	if n < 0 || n > len(s.data) {
+		return nil, false
+	}
+	start := len(s.data) - n
+	view := s.data[start:len(s.data):len(s.data)]
+	s.data = s.data[:start]
+	return view, true
+}
+
+// PeekN returns the last n elements as a zero-copy view without removing them.
+// Returns (nil, false) if n is negative or greater than Len.
+func (s *Vec[T]) PeekN(n int) ([]T, bool) {
+	if n < 0 || n > len(s.data) {
+		return nil, false
+	}
+	return s.data[len(s.data)-n : len(s.data) : len(s.data)], true
+}
+
+// PushHeap adds v to a Vec used as a binary min-heap ordered by less (so the element
+// for which less reports "smallest" is popped first), in O(log n). It lets a plain Vec
+// serve as a priority queue without a separate type.
+// ⚠️ CAUTION: the heap invariant only holds while the Vec is changed exclusively through
+// PushHeap and PopHeap with the same less; any other mutation (Set, Insert, Sort, ...)
+// breaks it. Reading via Get, Len or All is fine.
+//
+// Example:
+//
+// pq := NewVec[int](a)
+// less := func(x, y int) bool { return x < y }
+// pq.PushHeap(5, less)
+// pq.PushHeap(1, less)
+// top, _ := pq.PopHeap(less) // 1
+func (s *Vec[T]) PushHeap(v T, less func(a, b T) bool) {
+	s.AppendOne(v)
+	// Sift up: swap with the parent while smaller than it
+	i := len(s.data) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !less(s.data[i], s.data[parent]) {
+			break
+		}
+		s.data[i], s.data[parent] = s.data[parent], s.data[i]
+		i = parent
+	}
+}
+
+// PopHeap removes and returns the smallest element of a Vec maintained by PushHeap,
+// in O(log n). Returns (zero, false) if the Vec is empty.
+func (s *Vec[T]) PopHeap(less func(a, b T) bool) (T, bool) {
+	n := len(s.data) - 1
+	if n < 0 {
+		var zero T
+		return zero, false
+	}
+	top := s.data[0]
+	s.data[0] = s.data[n]
+	s.data = s.data[:n]
+	// Sift down: swap with the smaller child while larger than it
+	i := 0
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && less(s.data[right], s.data[child]) {
+			child = right
+		}
+		if !less(s.data[child], s.data[i]) {
+			break
+		}
+		s.data[i], s.data[child] = s.data[child], s.data[i]
+		i = child
+	}
+	return top, true
+}
+
 // Get returns element at index (safe)
 func (s *Vec[T]) Get(i int) (T, bool) {
 	if i < 0 || i >= len(s.data) {
@@ -272,11 +498,15 @@ func (s *Vec[T]) Set(i int, v T) bool {
 	return true
 }
 
-// Insert at index (shifts elements)
+// Insert at index (shifts elements). Inserting at Len appends without shifting.
 func (s *Vec[T]) Insert(i int, v T) bool {
 	if i < 0 || i > len(s.data) {
 		return false
 	}
+	if i == len(s.data) {
+		s.AppendOne(v)
+		return true
+	}
 	s.ensure(len(s.data) + 1)
 	s.data = s.data[:len(s.data)+1]
 	copy(s.data[i+1:], s.data[i:len(s.data)-1])
@@ -284,6 +514,26 @@ func (s *Vec[T]) Insert(i int, v T) bool {
 	return true
 }
 
+// Prepend inserts v at the front, shifting every element once. Each call is O(n), so
+// building a Vec by repeated Prepend is O(n²); batch the elements with PrependSlice,
+// or Append them and Reverse once, for front-heavy workloads.
+func (s *Vec[T]) Prepend(v T) {
+	s.Insert(0, v)
+}
+
+// PrependSlice inserts src at the front, keeping its order, with a single shift of
+// the existing elements
+func (s *Vec[T]) PrependSlice(src []T) {
+	if len(src) == 0 {
+		return
+	}
+	n := len(s.data)
+	s.ensure(n + len(src))
+	s.data = s.data[:n+len(src)]
+	copy(s.data[len(src):], s.data[:n])
+	copy(s.data, src)
+}
+
 // Remove at index (shifts elements)
 func (s *Vec[T]) Remove(i int) bool {
 	if i < 0 || i >= len(s.data) {
@@ -317,11 +567,107 @@ func (s *Vec[T]) RemoveBy(limit int, fn func(index int, v T) bool) int {
 	return removed
 }
 
-// Clear keeps capacity
+// RemoveIndices removes the elements at the given indices in a single compacting pass,
+// preserving the order of the remaining elements. Indices may be given in any order;
+// duplicates and out-of-range indices are ignored. The vacated tail is zeroed.
+// Sorted indices are used as is; unsorted ones are sorted in a scratch copy on the Go
+// heap, never in the arena. Returns the number of elements removed.
+//
+// Example:
+//
+//	slice := NewVec[string](a, "a", "b", "c", "d", "e")
+//	removed := slice.RemoveIndices([]int{3, 1, 3, 9})
+//	// removed = 2, slice contains [a, c, e]
+func (s *Vec[T]) RemoveIndices(indices []int) int {
+	if len(indices) == 0 || len(s.data) == 0 {
+		return 0
+	}
+	// Sort a scratch copy so the caller's slice is left untouched
+	sorted := indices
+	if !slices.IsSorted(sorted) {
+		sorted = slices.Clone(indices)
+		slices.Sort(sorted)
+	}
+
+	var (
+		n    = 0
+		next = 0
+	)
+	for i, v := range s.data {
+		for next < len(sorted) && sorted[next] < i {
+			next++
+		}
+		if next < len(sorted) && sorted[next] == i {
+			continue
+		}
+		s.data[n] = v
+		n++
+	}
+	removed := len(s.data) - n
+	clear(s.data[n:])
+	s.data = s.data[:n]
+	return removed
+}
+
+// CompactFunc removes, in place, every element for which keep returns false,
+// preserving the order of the kept elements. The vacated tail is zeroed so it
+// doesn't hold on to stale values. Returns the new length.
+//
+// Example:
+//
+//	slice := NewVec[int](a, 1, -2, 3, -4)
+//	n := slice.CompactFunc(func(v int) bool { return v > 0 })
+//	// n = 2, slice contains [1, 3]
+func (s *Vec[T]) CompactFunc(keep func(T) bool) int {
+	n := 0
+	for _, v := range s.data {
+		if keep(v) {
+			s.data[n] = v
+			n++
+		}
+	}
+	clear(s.data[n:])
+	s.data = s.data[:n]
+	return n
+}
+
+// VecCompactZero removes zero-valued elements from s in place and returns the new length
+func VecCompactZero[T comparable](s *Vec[T]) int {
+	var zero T
+	return s.CompactFunc(func(v T) bool { return v != zero })
+}
+
+// Transform replaces each element with fn applied to it, in place.
+// Length and capacity are unchanged and nothing is allocated.
+//
+// Example:
+//
+//	slice := NewVec[int](a, -5, 3, 12)
+//	slice.Transform(func(v int) int { return min(max(v, 0), 10) })
+//	// slice contains [0, 3, 10]
+func (s *Vec[T]) Transform(fn func(T) T) {
+	for i, v := range s.data {
+		s.data[i] = fn(v)
+	}
+}
+
+// Clear keeps capacity.
+// It is the fastest way to empty a Vec, but the old element values stay in the
+// spare capacity; use ClearZero for element types that hold references.
 func (s *Vec[T]) Clear() {
 	s.data = s.data[:0]
 }
 
+// ClearZero zeroes all elements and then truncates to length 0, keeping capacity.
+// Arena memory is not scanned by the garbage collector, so stale pointers left in the
+// spare capacity by Clear are never followed; they can however be resurrected by
+// re-slicing the backing array, and they keep pointing at whatever they referenced.
+// ClearZero guarantees the spare capacity holds only zero values.
+func (s *Vec[T]) ClearZero() {
+	clear(s.data)
+	s.data = s.data[:0]
+}
+
 // Resize to exact length (zero-fill if growing)
 func (s *Vec[T]) Resize(n int) {
 	if n <= len(s.data) {
@@ -353,6 +699,33 @@ func (s *Vec[T]) Reverse() {
 	}
 }
 
+// Shuffle randomly permutes the elements in place (Fisher-Yates) using r, so the
+// permutation is reproducible from the seed and avoids the global rand lock
+func (s *Vec[T]) Shuffle(r *rand.Rand) {
+	for i := len(s.data) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		s.data[i], s.data[j] = s.data[j], s.data[i]
+	}
+}
+
+// Sample returns k elements chosen uniformly at random without replacement, using
+// reservoir sampling with r, as a new slice allocated in the Vec's arena.
+// If k >= Len, all elements are returned in their original order; if k <= 0, nil.
+func (s *Vec[T]) Sample(r *rand.Rand, k int) []T {
+	k = min(k, len(s.data))
+	if k <= 0 {
+		return nil
+	}
+	result := MakeSlice[T](s.arena, k, k)
+	copy(result, s.data[:k])
+	for i := k; i < len(s.data); i++ {
+		if j := r.Intn(i + 1); j < k {
+			result[j] = s.data[i]
+		}
+	}
+	return result
+}
+
 // Sort (for ordered types)
 // ⚠️ CAUTION: The comparison function may cause closure allocations.
 func (s *Vec[T]) Sort(less func(a, b T) bool) {
@@ -377,6 +750,33 @@ func (s *Vec[T]) SortBy(cmpFn func(a, b T) int) {
 	s.Sort(func(a, b T) bool { return cmpFn(a, b) < 0 })
 }
 
+// BinarySearch searches a Vec sorted by cmp for v and returns the position where v
+// is found, or where it would be inserted, and whether it was found
+func (s *Vec[T]) BinarySearch(v T, cmp func(a, b T) int) (int, bool) {
+	return slices.BinarySearchFunc(s.data, v, cmp)
+}
+
+// SortedInsertUnique inserts v into a Vec sorted by cmp, keeping it sorted and free
+// of duplicates. If an equal element already exists, its index and false are returned
+// and nothing is inserted; otherwise v is inserted and its index and true are returned.
+// This makes a small sorted Vec usable as an ordered set with O(log n) lookup.
+//
+// Example:
+//
+//	set := NewVec[int](a)
+//	set.SortedInsertUnique(3, cmp.Compare[int]) // 0, true
+//	set.SortedInsertUnique(1, cmp.Compare[int]) // 0, true
+//	set.SortedInsertUnique(3, cmp.Compare[int]) // 1, false
+//	// set contains [1, 3]
+func (s *Vec[T]) SortedInsertUnique(v T, cmp func(a, b T) int) (int, bool) {
+	i, found := slices.BinarySearchFunc(s.data, v, cmp)
+	if found {
+		return i, false
+	}
+	s.Insert(i, v)
+	return i, true
+}
+
 // Contains
 // ⚠️ CAUTION: Using any() for comparison may cause interface allocations.
 func (s *Vec[T]) Contains(v T) bool {
@@ -504,6 +904,29 @@ func (s *Vec[T]) All2() iter.Seq2[int, T] {
 	}
 }
 
+// Each calls fn for every element in order.
+// A plain callback with no iterator machinery, so it never allocates.
+// Mutating the Vec from inside fn is the caller's responsibility: fn sees the
+// backing array as it was when Each started.
+//
+// Example:
+//
+// sum := 0
+// slice.Each(func(v int) { sum += v })
+func (s *Vec[T]) Each(fn func(v T)) {
+	for _, v := range s.data {
+		fn(v)
+	}
+}
+
+// EachIndexed calls fn for every index-value pair in order.
+// The same mutation caveat as Each applies.
+func (s *Vec[T]) EachIndexed(fn func(i int, v T)) {
+	for i, v := range s.data {
+		fn(i, v)
+	}
+}
+
 // SliceIter provides pull-based iteration
 // Similar to channels or iterators in other languages.
 type SliceIter[T any] struct {
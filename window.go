@@ -0,0 +1,112 @@
+package arena
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// SlidingWindow counts events over a trailing time window, for rate limiting and
+// metrics. The window is split into fixed-duration buckets kept in a ring in arena
+// memory; a bucket is reused once its time has passed out of the window, so the
+// structure never grows no matter how many events it sees. Counts are exact to
+// bucket granularity: an event stops counting when its whole bucket expires.
+// Thread-safe: Add and Count are serialized by a mutex.
+//
+// Example:
+//
+//	w := arena.NewSlidingWindow(a, time.Minute, time.Second)
+//	w.Add(time.Now(), 1)
+//	if w.Count(time.Now()) > limit {
+//	    reject()
+//	}
+type SlidingWindow struct {
+	mu      sync.Mutex
+	bucket  int64          // bucket duration in nanoseconds
+	buckets []windowBucket // ring indexed by bucket number modulo its length
+}
+
+// windowBucket holds the events of one bucket-duration interval; id is the interval
+// number (time / bucket duration), or math.MinInt64 for a never-used slot
+type windowBucket struct {
+	id    int64
+	count int64
+}
+
+// NewSlidingWindow creates a SlidingWindow covering windowDuration, rounded up to a
+// whole number of bucketDuration buckets. It panics if bucketDuration is not positive
+// or exceeds windowDuration.
+func NewSlidingWindow(a *Arena, windowDuration, bucketDuration time.Duration) *SlidingWindow {
+	if bucketDuration <= 0 || windowDuration < bucketDuration {
+		panic("arena sliding window: bucket duration must be positive and at most the window duration")
+	}
+	n := int((windowDuration + bucketDuration - 1) / bucketDuration)
+	buckets := MakeSliceN[windowBucket](a, n)
+	for i := range buckets {
+		buckets[i] = windowBucket{id: math.MinInt64}
+	}
+	return &SlidingWindow{bucket: int64(bucketDuration), buckets: buckets}
+}
+
+// Add records n events at time now. Events older than the window are ignored.
+func (w *SlidingWindow) Add(now time.Time, n int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.bucketID(now)
+	b := &w.buckets[w.slot(id)]
+	switch {
+	case b.id == id:
+		b.count += n
+	case b.id < id:
+		// The slot holds an expired bucket: reuse it
+		*b = windowBucket{id: id, count: n}
+	}
+}
+
+// Count returns the number of events in the window ending at now: the current
+// bucket and the buckets before it that fit in the window
+func (w *SlidingWindow) Count(now time.Time) int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var (
+		id     = w.bucketID(now)
+		oldest = id - int64(len(w.buckets)) + 1
+		total  int64
+	)
+	for _, b := range w.buckets {
+		if b.id >= oldest && b.id <= id {
+			total += b.count
+		}
+	}
+	return total
+}
+
+// Reset discards all recorded events
+func (w *SlidingWindow) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range w.buckets {
+		w.buckets[i] = windowBucket{id: math.MinInt64}
+	}
+}
+
+// bucketID returns the number of the bucket containing t
+func (w *SlidingWindow) bucketID(t time.Time) int64 {
+	ns := t.UnixNano()
+	id := ns / w.bucket
+	if ns%w.bucket < 0 {
+		id-- // floor division for times before 1970
+	}
+	return id
+}
+
+// slot returns the ring index of bucket id
+func (w *SlidingWindow) slot(id int64) int {
+	i := id % int64(len(w.buckets))
+	if i < 0 {
+		i += int64(len(w.buckets))
+	}
+	return int(i)
+}